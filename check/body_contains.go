@@ -0,0 +1,47 @@
+package check
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// BodyMissingValueError is returned when a check fails.
+type BodyMissingValueError struct {
+	// Expected is the value that should be contained in the body.
+	Expected string
+}
+
+// Error returns an error string.
+func (e *BodyMissingValueError) Error() string {
+	return fmt.Sprintf("body does not contain %q", e.Expected)
+}
+
+// BodyContainsChecker is used to validate the http response body contains `Value`.
+// If `ResolveData` is true, `Value` may be a data reference.
+type BodyContainsChecker struct {
+	Value       string
+	ResolveData bool
+}
+
+// Check performs the BodyContains check
+func (c *BodyContainsChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	expected, err := resolveOptionalDataReferenceString(ctx, c.ResolveData, c.Value)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Contains(body, []byte(expected)) {
+		return &BodyMissingValueError{
+			Expected: expected,
+		}
+	}
+
+	return nil
+}
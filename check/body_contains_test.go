@@ -0,0 +1,116 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestBodyContainsChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := [...]struct {
+		value       string
+		expectedErr string
+	}{
+		{value: "Hello"},
+		{value: "world!"},
+		{value: "$.greeting"},
+		{value: "hello", expectedErr: `body does not contain "hello"`},
+		{value: "$.missing", expectedErr: "data id `missing` has not been stored"},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := check.ContextWithData(context.Background(), map[string]interface{}{"greeting": "Hello"})
+			err := (&check.BodyContainsChecker{Value: tc.value, ResolveData: true}).Check(ctx, responseWithBody("Hello, world!"))
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			} else if err == nil {
+				t.Errorf("expected error but got none")
+			} else if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
+
+func TestBodyNotContainsChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := [...]struct {
+		value       string
+		expectedErr string
+	}{
+		{value: "Stack trace"},
+		{value: "hello"},
+		{value: "world", expectedErr: `body contains "world" at index 7`},
+		{value: "$.greeting", expectedErr: `body contains "Hello" at index 0`},
+		{value: "", expectedErr: "value must not be empty"},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := check.ContextWithData(context.Background(), map[string]interface{}{"greeting": "Hello"})
+			err := (&check.BodyNotContainsChecker{Value: tc.value, ResolveData: true}).Check(ctx, responseWithBody("Hello, world!"))
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			} else if err == nil {
+				t.Errorf("expected error but got none")
+			} else if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
+
+func TestBodyRegexMatchChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := [...]struct {
+		pattern      string
+		dataIDs      map[int]string
+		expectedErr  string
+		expectedData map[string]interface{}
+	}{
+		{pattern: `^Hello`},
+		{pattern: `id=(\d+)`, dataIDs: map[int]string{0: "match", 1: "id"}, expectedData: map[string]interface{}{"match": "id=123", "id": "123"}},
+		{pattern: `^world`, expectedErr: "body does not match pattern ^world"},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.pattern, func(t *testing.T) {
+			t.Parallel()
+
+			data := make(map[string]interface{})
+			ctx := check.ContextWithData(context.Background(), data)
+			checker := &check.BodyRegexMatchChecker{Regexp: regexp.MustCompile(tc.pattern), DataIDs: tc.dataIDs}
+			err := checker.Check(ctx, responseWithBody("Hello, world! id=123"))
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			} else if err == nil {
+				t.Errorf("expected error but got none")
+			} else if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+			if tc.expectedData != nil && !reflect.DeepEqual(tc.expectedData, data) {
+				t.Errorf("expected data %v, got %v", tc.expectedData, data)
+			}
+		})
+	}
+}
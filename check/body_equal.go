@@ -19,9 +19,11 @@ func (e *UnexpectedValueError) Error() string {
 	return fmt.Sprintf("unexpected value: expected %v, got %v", e.Expected, e.Actual)
 }
 
-// BodyEqualChecker is used to validate the http response body string exactly matches `Value`
+// BodyEqualChecker is used to validate the http response body string exactly matches `Value`.
+// If `ResolveData` is true, `Value` may be a data reference.
 type BodyEqualChecker struct {
-	Value string
+	Value       string
+	ResolveData bool
 }
 
 // Check performs the BodyEqual check
@@ -31,7 +33,12 @@ func (c *BodyEqualChecker) Check(ctx context.Context, response *http.Response) e
 		return err
 	}
 
-	if exp, got := c.Value, string(body); exp != got {
+	expected, err := resolveOptionalDataReferenceString(ctx, c.ResolveData, c.Value)
+	if err != nil {
+		return err
+	}
+
+	if exp, got := expected, string(body); exp != got {
 		return &UnexpectedValueError{
 			Expected: exp,
 			Actual:   got,
@@ -0,0 +1,57 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// UnexpectedHTMLSelectorCountError is returned when a check fails.
+type UnexpectedHTMLSelectorCountError struct {
+	// Selector is the CSS selector.
+	Selector string
+	// Expected is a description of the expected count.
+	Expected string
+	// Actual is the actual count.
+	Actual int
+}
+
+// Error returns an error string.
+func (e *UnexpectedHTMLSelectorCountError) Error() string {
+	return fmt.Sprintf("unexpected number of elements matching %v: expected %v, got %v", e.Selector, e.Expected, e.Actual)
+}
+
+// BodyHTMLSelectorCountChecker ensures that the expected number of elements in the http response body HTML match the CSS selector in `Selector`.
+// Each of `Value`, `Min` and `Max` are optional.
+type BodyHTMLSelectorCountChecker struct {
+	Selector string
+	Value    *int
+	Min      *int
+	Max      *int
+}
+
+// Check performs the BodyHTMLSelectorCount check
+func (c *BodyHTMLSelectorCountChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	selection, err := selectHTML(body, c.Selector)
+	if err != nil {
+		return err
+	}
+
+	got := selection.Length()
+
+	expected, ok := countBounds{Value: c.Value, Min: c.Min, Max: c.Max}.check(got)
+	if ok {
+		return nil
+	}
+
+	return &UnexpectedHTMLSelectorCountError{
+		Selector: c.Selector,
+		Expected: expected,
+		Actual:   got,
+	}
+}
@@ -0,0 +1,69 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// UnexpectedHTMLValueError is returned when a check fails.
+type UnexpectedHTMLValueError struct {
+	// Selector is the CSS selector.
+	Selector string
+	// Attribute is the attribute name, if any.
+	Attribute string
+	// Expected is the expected value.
+	Expected string
+	// Actual is the actual value.
+	Actual string
+}
+
+// Error returns an error string.
+func (e *UnexpectedHTMLValueError) Error() string {
+	return fmt.Sprintf("unexpected value at %v: expected %v, got %v", htmlValueDescription(e.Selector, e.Attribute), e.Expected, e.Actual)
+}
+
+// BodyHTMLSelectorEqualChecker ensures that the first element in the http response body HTML matching the CSS selector in `Selector` has a value equal to `Value`.
+// If `Attribute` is empty the trimmed text of the element is checked, otherwise the value of the attribute is checked.
+// If `ResolveData` is true, `Value` may be a data reference.
+type BodyHTMLSelectorEqualChecker struct {
+	Selector    string
+	Attribute   string
+	Value       string
+	ResolveData bool
+	DataID      string
+}
+
+// Check performs the BodyHTMLSelectorEqual check
+func (c *BodyHTMLSelectorEqualChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	selection, err := selectHTML(body, c.Selector)
+	if err != nil {
+		return err
+	}
+
+	got, err := htmlSelectionValue(selection, c.Selector, c.Attribute)
+	if err != nil {
+		return err
+	}
+
+	expected, err := resolveOptionalDataReferenceString(ctx, c.ResolveData, c.Value)
+	if err != nil {
+		return err
+	}
+
+	if got != expected {
+		return &UnexpectedHTMLValueError{
+			Selector:  c.Selector,
+			Attribute: c.Attribute,
+			Expected:  expected,
+			Actual:    got,
+		}
+	}
+
+	return ContextWithOptionalDataID(ctx, c.DataID, got)
+}
@@ -0,0 +1,34 @@
+package check
+
+import (
+	"context"
+	"net/http"
+)
+
+// BodyHTMLSelectorExistsChecker ensures that at least one element in the http response body HTML matches the CSS selector in `Selector`.
+// If `Attribute` is not empty, the first matching element must also have that attribute.
+type BodyHTMLSelectorExistsChecker struct {
+	Selector  string
+	Attribute string
+	DataID    string
+}
+
+// Check performs the BodyHTMLSelectorExists check
+func (c *BodyHTMLSelectorExistsChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	selection, err := selectHTML(body, c.Selector)
+	if err != nil {
+		return err
+	}
+
+	got, err := htmlSelectionValue(selection, c.Selector, c.Attribute)
+	if err != nil {
+		return err
+	}
+
+	return ContextWithOptionalDataID(ctx, c.DataID, got)
+}
@@ -0,0 +1,63 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// UnexpectedHTMLRegexValueError is returned when a check fails.
+type UnexpectedHTMLRegexValueError struct {
+	// Pattern is the regex pattern.
+	Pattern string
+	// Selector is the CSS selector.
+	Selector string
+	// Attribute is the attribute name, if any.
+	Attribute string
+	// Actual is the actual value.
+	Actual string
+}
+
+// Error returns an error string.
+func (e *UnexpectedHTMLRegexValueError) Error() string {
+	return fmt.Sprintf("unexpected value at %v: does not match pattern %v: got %v", htmlValueDescription(e.Selector, e.Attribute), e.Pattern, e.Actual)
+}
+
+// BodyHTMLSelectorRegexMatchChecker ensures that the first element in the http response body HTML matching the CSS selector in `Selector` matches the regex pattern in `Regexp`.
+// If `Attribute` is empty the trimmed text of the element is checked, otherwise the value of the attribute is checked.
+type BodyHTMLSelectorRegexMatchChecker struct {
+	Selector  string
+	Attribute string
+	Regexp    *regexp.Regexp
+	DataIDs   map[int]string
+}
+
+// Check performs the BodyHTMLSelectorRegexMatch check
+func (c *BodyHTMLSelectorRegexMatchChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	selection, err := selectHTML(body, c.Selector)
+	if err != nil {
+		return err
+	}
+
+	got, err := htmlSelectionValue(selection, c.Selector, c.Attribute)
+	if err != nil {
+		return err
+	}
+
+	if !c.Regexp.MatchString(got) {
+		return &UnexpectedHTMLRegexValueError{
+			Pattern:   c.Regexp.String(),
+			Selector:  c.Selector,
+			Attribute: c.Attribute,
+			Actual:    got,
+		}
+	}
+
+	return contextWithRegexMatches(ctx, c.Regexp, got, c.DataIDs)
+}
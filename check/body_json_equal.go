@@ -14,16 +14,33 @@ type UnexpectedJSONBodyError struct {
 	Expected interface{}
 	// Actual is the actual value.
 	Actual interface{}
+	// Differences contains each difference between the expected and actual values.
+	Differences JSONDiff
 }
 
 // Error returns an error string.
 func (e *UnexpectedJSONBodyError) Error() string {
-	return fmt.Sprintf("unexpected value: expected %v, got %v", e.Expected, e.Actual)
+	return e.render(false)
 }
 
-// BodyJSONChecker is used to validate http response body can be JSON decoded and is equal to `Value`
+// ColourError returns an error string with a coloured diff.
+func (e *UnexpectedJSONBodyError) ColourError() string {
+	return e.render(true)
+}
+
+func (e *UnexpectedJSONBodyError) render(colour bool) string {
+	if len(e.Differences) == 0 {
+		return fmt.Sprintf("unexpected value: expected %v, got %v", e.Expected, e.Actual)
+	}
+	return fmt.Sprintf("unexpected value: %d difference(s):\n%s", len(e.Differences), e.Differences.Render(colour))
+}
+
+// BodyJSONChecker is used to validate http response body can be JSON decoded and is equal to `Value`.
+// `Value` may be any JSON value: an object, array, string, number, boolean or null.
+// If `ResolveData` is true, data references within `Value` are resolved.
 type BodyJSONChecker struct {
-	Value interface{}
+	Value       interface{}
+	ResolveData bool
 }
 
 // Check performs the BodyJSON check
@@ -33,17 +50,30 @@ func (c *BodyJSONChecker) Check(ctx context.Context, response *http.Response) er
 		return err
 	}
 
-	var got map[string]interface{}
+	var got interface{}
 
 	err = json.Unmarshal(body, &got)
 	if err != nil {
 		return fmt.Errorf("could not unmarshal actual response: %w", err)
 	}
 
-	if !reflect.DeepEqual(c.Value, got) {
+	expected, err := resolveOptionalDataReferences(ctx, c.ResolveData, c.Value)
+	if err != nil {
+		return err
+	}
+
+	if exp, act := jsonType(expected), jsonType(got); exp != act {
+		return &UnexpectedJSONTypeError{
+			Expected: exp,
+			Actual:   act,
+		}
+	}
+
+	if !reflect.DeepEqual(expected, got) {
 		return &UnexpectedJSONBodyError{
-			Expected: c.Value,
-			Actual:   got,
+			Expected:    expected,
+			Actual:      got,
+			Differences: DiffJSON(expected, got),
 		}
 	}
 
@@ -0,0 +1,108 @@
+package check_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/tomwright/apitestr/check"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func responseWithBody(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func TestBodyJSONChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := [...]struct {
+		desc        string
+		value       interface{}
+		body        string
+		expectedErr bool
+		expectedTyp string
+	}{
+		{
+			desc:  "object",
+			value: map[string]interface{}{"id": float64(1)},
+			body:  `{"id":1}`,
+		},
+		{
+			desc:  "array",
+			value: []interface{}{float64(1), "two"},
+			body:  `[1,"two"]`,
+		},
+		{
+			desc:  "string",
+			value: "hello",
+			body:  `"hello"`,
+		},
+		{
+			desc:  "number",
+			value: float64(5),
+			body:  `5`,
+		},
+		{
+			desc:  "null",
+			value: nil,
+			body:  `null`,
+		},
+		{
+			desc:        "array instead of object",
+			value:       map[string]interface{}{"id": float64(1)},
+			body:        `[{"id":1}]`,
+			expectedErr: true,
+			expectedTyp: "array",
+		},
+		{
+			desc:        "string instead of number",
+			value:       float64(1),
+			body:        `"1"`,
+			expectedErr: true,
+			expectedTyp: "string",
+		},
+		{
+			desc:        "different array",
+			value:       []interface{}{float64(1)},
+			body:        `[2]`,
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			c := &check.BodyJSONChecker{Value: tc.value}
+			err := c.Check(context.Background(), responseWithBody(tc.body))
+			if !tc.expectedErr {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("expected error but got none")
+				return
+			}
+			if tc.expectedTyp == "" {
+				return
+			}
+			var typeErr *check.UnexpectedJSONTypeError
+			if !errors.As(err, &typeErr) {
+				t.Errorf("expected UnexpectedJSONTypeError, got %T: %s", err, err)
+				return
+			}
+			if exp, got := tc.expectedTyp, typeErr.Actual; exp != got {
+				t.Errorf("expected actual type of `%s`, got `%s`", exp, got)
+			}
+		})
+	}
+}
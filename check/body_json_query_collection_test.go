@@ -0,0 +1,53 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"testing"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestBodyJSONQueryCollectionCheckers(t *testing.T) {
+	t.Parallel()
+
+	body := `{"items":[{"id":1,"name":"a","tags":["x"]},{"id":2,"name":"b","tags":["y"]},{"id":2,"name":"c","tags":["z"]}],"other":{}}`
+
+	tests := [...]struct {
+		desc        string
+		checker     check.Checker
+		expectedErr bool
+	}{
+		{desc: "length equal", checker: &check.BodyJSONQueryLengthChecker{Query: "items", Value: intPtr(3)}},
+		{desc: "length not equal", checker: &check.BodyJSONQueryLengthChecker{Query: "items", Value: intPtr(2)}, expectedErr: true},
+		{desc: "length min max", checker: &check.BodyJSONQueryLengthChecker{Query: "items", Min: intPtr(1), Max: intPtr(3)}},
+		{desc: "length above max", checker: &check.BodyJSONQueryLengthChecker{Query: "items", Max: intPtr(2)}, expectedErr: true},
+		{desc: "length of non array", checker: &check.BodyJSONQueryLengthChecker{Query: "other", Min: intPtr(0)}, expectedErr: true},
+		{desc: "unique by name", checker: &check.BodyJSONQueryUniqueChecker{Query: "items", Path: "name"}},
+		{desc: "unique by id", checker: &check.BodyJSONQueryUniqueChecker{Query: "items", Path: "id"}, expectedErr: true},
+		{desc: "unique elements", checker: &check.BodyJSONQueryUniqueChecker{Query: "items"}},
+		{desc: "sorted asc", checker: &check.BodyJSONQuerySortedChecker{Query: "items", By: "id"}},
+		{desc: "sorted desc", checker: &check.BodyJSONQuerySortedChecker{Query: "items", By: "name", Direction: check.SortDescending}, expectedErr: true},
+		{desc: "sorted by missing path", checker: &check.BodyJSONQuerySortedChecker{Query: "items", By: "nope"}, expectedErr: true},
+		{desc: "contains partial", checker: &check.BodyJSONQueryContainsChecker{Query: "items", Value: map[string]interface{}{"name": "b"}}},
+		{desc: "contains nested", checker: &check.BodyJSONQueryContainsChecker{Query: "items", Value: map[string]interface{}{"id": float64(2), "tags": []interface{}{"z"}}}},
+		{desc: "does not contain", checker: &check.BodyJSONQueryContainsChecker{Query: "items", Value: map[string]interface{}{"id": float64(1), "name": "b"}}, expectedErr: true},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.checker.Check(context.Background(), responseWithBody(body))
+			if tc.expectedErr && err == nil {
+				t.Errorf("expected error but got none")
+			}
+			if !tc.expectedErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"github.com/tidwall/gjson"
+	"math"
+	"net/http"
+	"strings"
+)
+
+// CompareOperator defines how a value is compared to the expected value.
+type CompareOperator string
+
+const (
+	// CompareGreaterThan ensures the value is greater than the expected value.
+	CompareGreaterThan CompareOperator = "gt"
+	// CompareGreaterThanOrEqual ensures the value is greater than or equal to the expected value.
+	CompareGreaterThanOrEqual CompareOperator = "gte"
+	// CompareLessThan ensures the value is less than the expected value.
+	CompareLessThan CompareOperator = "lt"
+	// CompareLessThanOrEqual ensures the value is less than or equal to the expected value.
+	CompareLessThanOrEqual CompareOperator = "lte"
+	// CompareBetween ensures the value is between the min and max values, inclusive.
+	CompareBetween CompareOperator = "between"
+	// CompareApprox ensures the value is within a tolerance of the expected value.
+	CompareApprox CompareOperator = "approx"
+)
+
+// CompareMode defines how values are interpreted when they are compared.
+type CompareMode string
+
+const (
+	// CompareModeNumber compares numbers.
+	CompareModeNumber CompareMode = "number"
+	// CompareModeString compares strings lexically.
+	CompareModeString CompareMode = "string"
+	// CompareModeSemver compares strings as semantic versions.
+	CompareModeSemver CompareMode = "semver"
+)
+
+// UnexpectedJSONQueryComparisonError is returned when a check fails.
+type UnexpectedJSONQueryComparisonError struct {
+	// Query is the JSON query.
+	Query string
+	// Expected is a description of the expected value.
+	Expected string
+	// Actual is the actual value.
+	Actual interface{}
+}
+
+// Error returns an error string.
+func (e *UnexpectedJSONQueryComparisonError) Error() string {
+	return fmt.Sprintf("unexpected value at %v: expected %v, got %v", e.Query, e.Expected, e.Actual)
+}
+
+// BodyJSONQueryCompareChecker queries the http response body JSON using `Query` and compares the value against `Value` using `Operator`.
+// `Min` and `Max` are used by the between operator, and `Tolerance` is used by the approx operator.
+// If `Mode` is empty, strings are compared lexically and everything else is compared as a number.
+// If `ResolveData` is true, `Value`, `Min` and `Max` may be data references.
+type BodyJSONQueryCompareChecker struct {
+	Query       string
+	Operator    CompareOperator
+	Mode        CompareMode
+	Value       interface{}
+	Min         interface{}
+	Max         interface{}
+	Tolerance   float64
+	ResolveData bool
+	DataID      string
+}
+
+// Check performs the BodyJSONQueryCompare check
+func (c *BodyJSONQueryCompareChecker) Check(ctx context.Context, response *http.Response) error {
+	c, err := c.withDataReferences(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	j := gjson.ParseBytes(body)
+
+	r := j.Get(c.Query)
+
+	if !r.Exists() {
+		return &JSONQueryValueMissingError{
+			Query: c.Query,
+		}
+	}
+
+	mode := c.mode()
+
+	expectedType := JSONTypeString
+	if mode == CompareModeNumber {
+		expectedType = JSONTypeNumber
+	}
+	if actualType := jsonType(r.Value()); actualType != expectedType {
+		return &UnexpectedJSONTypeError{
+			Query:    c.Query,
+			Expected: expectedType,
+			Actual:   actualType,
+		}
+	}
+
+	ok, err := c.compare(mode, r.Value())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &UnexpectedJSONQueryComparisonError{
+			Query:    c.Query,
+			Expected: c.describe(),
+			Actual:   r.Value(),
+		}
+	}
+
+	return ContextWithOptionalDataID(ctx, c.DataID, r.Value())
+}
+
+// withDataReferences returns a copy of the checker with data references in the expected values resolved.
+func (c *BodyJSONQueryCompareChecker) withDataReferences(ctx context.Context) (*BodyJSONQueryCompareChecker, error) {
+	resolved := *c
+	var err error
+	if resolved.Value, err = resolveOptionalDataReferences(ctx, c.ResolveData, c.Value); err != nil {
+		return nil, err
+	}
+	if resolved.Min, err = resolveOptionalDataReferences(ctx, c.ResolveData, c.Min); err != nil {
+		return nil, err
+	}
+	if resolved.Max, err = resolveOptionalDataReferences(ctx, c.ResolveData, c.Max); err != nil {
+		return nil, err
+	}
+	return &resolved, nil
+}
+
+func (c *BodyJSONQueryCompareChecker) mode() CompareMode {
+	if c.Mode != "" {
+		return c.Mode
+	}
+	expected := c.Value
+	if c.Operator == CompareBetween {
+		expected = c.Min
+	}
+	if _, ok := expected.(string); ok {
+		return CompareModeString
+	}
+	return CompareModeNumber
+}
+
+func (c *BodyJSONQueryCompareChecker) compare(mode CompareMode, actual interface{}) (bool, error) {
+	if c.Operator == CompareBetween {
+		minRes, err := compareValues(mode, actual, c.Min)
+		if err != nil {
+			return false, err
+		}
+		maxRes, err := compareValues(mode, actual, c.Max)
+		if err != nil {
+			return false, err
+		}
+		return minRes >= 0 && maxRes <= 0, nil
+	}
+
+	if c.Operator == CompareApprox {
+		if mode != CompareModeNumber {
+			return false, fmt.Errorf("operator `%s` can only be used with numbers", c.Operator)
+		}
+		act, err := toFloat(actual)
+		if err != nil {
+			return false, err
+		}
+		exp, err := toFloat(c.Value)
+		if err != nil {
+			return false, err
+		}
+		return math.Abs(act-exp) <= c.Tolerance, nil
+	}
+
+	res, err := compareValues(mode, actual, c.Value)
+	if err != nil {
+		return false, err
+	}
+
+	switch c.Operator {
+	case CompareGreaterThan:
+		return res > 0, nil
+	case CompareGreaterThanOrEqual:
+		return res >= 0, nil
+	case CompareLessThan:
+		return res < 0, nil
+	case CompareLessThanOrEqual:
+		return res <= 0, nil
+	}
+	return false, fmt.Errorf("unhandled compare operator `%s`", c.Operator)
+}
+
+func (c *BodyJSONQueryCompareChecker) describe() string {
+	switch c.Operator {
+	case CompareGreaterThan:
+		return fmt.Sprintf("a value > %v", c.Value)
+	case CompareGreaterThanOrEqual:
+		return fmt.Sprintf("a value >= %v", c.Value)
+	case CompareLessThan:
+		return fmt.Sprintf("a value < %v", c.Value)
+	case CompareLessThanOrEqual:
+		return fmt.Sprintf("a value <= %v", c.Value)
+	case CompareBetween:
+		return fmt.Sprintf("a value between %v and %v", c.Min, c.Max)
+	case CompareApprox:
+		return fmt.Sprintf("a value within %v of %v", c.Tolerance, c.Value)
+	}
+	return fmt.Sprintf("a value %s %v", c.Operator, c.Value)
+}
+
+// compareValues returns -1, 0 or 1 if a is less than, equal to or greater than b when compared using the given mode.
+func compareValues(mode CompareMode, a interface{}, b interface{}) (int, error) {
+	switch mode {
+	case CompareModeNumber:
+		aFloat, err := toFloat(a)
+		if err != nil {
+			return 0, err
+		}
+		bFloat, err := toFloat(b)
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case aFloat < bFloat:
+			return -1, nil
+		case aFloat > bFloat:
+			return 1, nil
+		}
+		return 0, nil
+
+	case CompareModeString:
+		aStr, err := toString(a)
+		if err != nil {
+			return 0, err
+		}
+		bStr, err := toString(b)
+		if err != nil {
+			return 0, err
+		}
+		return strings.Compare(aStr, bStr), nil
+
+	case CompareModeSemver:
+		aStr, err := toString(a)
+		if err != nil {
+			return 0, err
+		}
+		bStr, err := toString(b)
+		if err != nil {
+			return 0, err
+		}
+		aVer, err := parseSemver(aStr)
+		if err != nil {
+			return 0, err
+		}
+		bVer, err := parseSemver(bStr)
+		if err != nil {
+			return 0, err
+		}
+		return aVer.compare(bVer), nil
+	}
+
+	return 0, fmt.Errorf("unhandled compare mode `%s`", mode)
+}
+
+func toFloat(val interface{}) (float64, error) {
+	switch v := val.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	}
+	return 0, fmt.Errorf("expected number, got `%T` with value `%v`", val, val)
+}
+
+func toString(val interface{}) (string, error) {
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	}
+	return "", fmt.Errorf("expected string, got `%T` with value `%v`", val, val)
+}
@@ -0,0 +1,107 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"testing"
+)
+
+func TestBodyJSONQueryCompareChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	body := `{"total":3,"price":9.99,"name":"bravo","version":"v2.3.1","pre":"2.3.0-beta.2"}`
+
+	tests := [...]struct {
+		desc        string
+		checker     *check.BodyJSONQueryCompareChecker
+		expectedErr bool
+	}{
+		{
+			desc:    "gt",
+			checker: &check.BodyJSONQueryCompareChecker{Query: "total", Operator: check.CompareGreaterThan, Value: float64(2)},
+		},
+		{
+			desc:        "gt equal",
+			checker:     &check.BodyJSONQueryCompareChecker{Query: "total", Operator: check.CompareGreaterThan, Value: float64(3)},
+			expectedErr: true,
+		},
+		{
+			desc:    "gte",
+			checker: &check.BodyJSONQueryCompareChecker{Query: "total", Operator: check.CompareGreaterThanOrEqual, Value: 3},
+		},
+		{
+			desc:    "lt",
+			checker: &check.BodyJSONQueryCompareChecker{Query: "price", Operator: check.CompareLessThan, Value: float64(10)},
+		},
+		{
+			desc:        "lte",
+			checker:     &check.BodyJSONQueryCompareChecker{Query: "price", Operator: check.CompareLessThanOrEqual, Value: float64(9)},
+			expectedErr: true,
+		},
+		{
+			desc:    "between",
+			checker: &check.BodyJSONQueryCompareChecker{Query: "total", Operator: check.CompareBetween, Min: float64(1), Max: float64(3)},
+		},
+		{
+			desc:        "not between",
+			checker:     &check.BodyJSONQueryCompareChecker{Query: "total", Operator: check.CompareBetween, Min: float64(4), Max: float64(6)},
+			expectedErr: true,
+		},
+		{
+			desc:    "approx",
+			checker: &check.BodyJSONQueryCompareChecker{Query: "price", Operator: check.CompareApprox, Value: float64(10), Tolerance: 0.05},
+		},
+		{
+			desc:        "not approx",
+			checker:     &check.BodyJSONQueryCompareChecker{Query: "price", Operator: check.CompareApprox, Value: float64(10), Tolerance: 0.001},
+			expectedErr: true,
+		},
+		{
+			desc:    "string",
+			checker: &check.BodyJSONQueryCompareChecker{Query: "name", Operator: check.CompareGreaterThan, Value: "alpha"},
+		},
+		{
+			desc:        "string type mismatch",
+			checker:     &check.BodyJSONQueryCompareChecker{Query: "name", Operator: check.CompareGreaterThan, Value: float64(1)},
+			expectedErr: true,
+		},
+		{
+			desc:    "semver",
+			checker: &check.BodyJSONQueryCompareChecker{Query: "version", Operator: check.CompareGreaterThanOrEqual, Mode: check.CompareModeSemver, Value: "2.3"},
+		},
+		{
+			desc:        "semver numeric parts",
+			checker:     &check.BodyJSONQueryCompareChecker{Query: "version", Operator: check.CompareGreaterThanOrEqual, Mode: check.CompareModeSemver, Value: "2.10.0"},
+			expectedErr: true,
+		},
+		{
+			desc:    "semver prerelease",
+			checker: &check.BodyJSONQueryCompareChecker{Query: "pre", Operator: check.CompareBetween, Mode: check.CompareModeSemver, Min: "2.3.0-beta.1", Max: "2.3.0-rc.1"},
+		},
+		{
+			desc:        "semver prerelease is less than release",
+			checker:     &check.BodyJSONQueryCompareChecker{Query: "pre", Operator: check.CompareGreaterThanOrEqual, Mode: check.CompareModeSemver, Value: "2.3.0"},
+			expectedErr: true,
+		},
+		{
+			desc:        "missing",
+			checker:     &check.BodyJSONQueryCompareChecker{Query: "nope", Operator: check.CompareGreaterThan, Value: float64(1)},
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.checker.Check(context.Background(), responseWithBody(body))
+			if tc.expectedErr && err == nil {
+				t.Errorf("expected error but got none")
+			}
+			if !tc.expectedErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
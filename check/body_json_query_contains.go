@@ -0,0 +1,80 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// JSONQueryNoMatchingElementError is returned when a check fails.
+type JSONQueryNoMatchingElementError struct {
+	// Query is the JSON query.
+	Query string
+	// Expected is the partial value that no element matched.
+	Expected interface{}
+}
+
+// Error returns an error string.
+func (e *JSONQueryNoMatchingElementError) Error() string {
+	return fmt.Sprintf("no element at %v matches %v", e.Query, fmtDiffValue(e.Expected))
+}
+
+// BodyJSONQueryContainsChecker queries the http response body JSON using `Query` and ensures that at least one element in the resulting array matches `Value`.
+// If `Value` is an object, an element matches if it contains each of the keys in `Value` with matching values. Any other keys are ignored.
+// If `ResolveData` is true, data references within `Value` are resolved.
+type BodyJSONQueryContainsChecker struct {
+	Query       string
+	Value       interface{}
+	ResolveData bool
+	DataID      string
+}
+
+// Check performs the BodyJSONQueryContains check
+func (c *BodyJSONQueryContainsChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	elements, err := queryJSONArray(body, c.Query)
+	if err != nil {
+		return err
+	}
+
+	expected, err := resolveOptionalDataReferences(ctx, c.ResolveData, c.Value)
+	if err != nil {
+		return err
+	}
+
+	for _, element := range elements {
+		if matchesPartialJSON(expected, element.Value()) {
+			return ContextWithOptionalDataID(ctx, c.DataID, element.Value())
+		}
+	}
+
+	return &JSONQueryNoMatchingElementError{
+		Query:    c.Query,
+		Expected: expected,
+	}
+}
+
+// matchesPartialJSON returns true if actual matches the partial expected value.
+// Objects match if actual contains every key in expected with a matching value. All other values must be equal.
+func matchesPartialJSON(expected interface{}, actual interface{}) bool {
+	expMap, ok := expected.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(expected, actual)
+	}
+	actMap, ok := actual.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for k, expVal := range expMap {
+		actVal, ok := actMap[k]
+		if !ok || !matchesPartialJSON(expVal, actVal) {
+			return false
+		}
+	}
+	return true
+}
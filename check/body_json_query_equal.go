@@ -16,19 +16,36 @@ type UnexpectedJSONQueryValueError struct {
 	Expected interface{}
 	// Actual is the actual value.
 	Actual interface{}
+	// Differences contains each difference between the expected and actual values.
+	// It is only populated when comparing objects or arrays.
+	Differences JSONDiff
 }
 
 // Error returns an error string.
 func (e *UnexpectedJSONQueryValueError) Error() string {
-	return fmt.Sprintf("unexpected value at %v: expected %v, got %v", e.Query, e.Expected, e.Actual)
+	return e.render(false)
 }
 
-// BodyJSONQueryEqualChecker queries the http response body JSON using `Query` and ensures the value is equal to `Value`
+// ColourError returns an error string with a coloured diff.
+func (e *UnexpectedJSONQueryValueError) ColourError() string {
+	return e.render(true)
+}
+
+func (e *UnexpectedJSONQueryValueError) render(colour bool) string {
+	if len(e.Differences) == 0 {
+		return fmt.Sprintf("unexpected value at %v: expected %v, got %v", e.Query, e.Expected, e.Actual)
+	}
+	return fmt.Sprintf("unexpected value at %v: %d difference(s):\n%s", e.Query, len(e.Differences), e.Differences.Render(colour))
+}
+
+// BodyJSONQueryEqualChecker queries the http response body JSON using `Query` and ensures the value is equal to `Value`.
+// If `ResolveData` is true, data references within `Value` are resolved.
 type BodyJSONQueryEqualChecker struct {
-	Query     string
-	Value     interface{}
-	NullValue bool
-	DataID    string
+	Query       string
+	Value       interface{}
+	NullValue   bool
+	ResolveData bool
+	DataID      string
 }
 
 // Check performs the BodyJSONQueryEqual check
@@ -48,12 +65,29 @@ func (c *BodyJSONQueryEqualChecker) Check(ctx context.Context, response *http.Re
 		}
 	}
 
-	if got := r.Value(); !reflect.DeepEqual(c.Value, got) {
-		return &UnexpectedJSONQueryValueError{
+	expected, err := resolveOptionalDataReferences(ctx, c.ResolveData, c.Value)
+	if err != nil {
+		return err
+	}
+
+	if exp, act := jsonType(expected), jsonType(r.Value()); exp != act {
+		return &UnexpectedJSONTypeError{
 			Query:    c.Query,
-			Expected: c.Value,
+			Expected: exp,
+			Actual:   act,
+		}
+	}
+
+	if got := r.Value(); !reflect.DeepEqual(expected, got) {
+		err := &UnexpectedJSONQueryValueError{
+			Query:    c.Query,
+			Expected: expected,
 			Actual:   got,
 		}
+		if r.IsObject() || r.IsArray() {
+			err.Differences = DiffJSON(expected, got).WithPathPrefix(c.Query)
+		}
+		return err
 	}
 
 	return ContextWithOptionalDataID(ctx, c.DataID, r.Value())
@@ -0,0 +1,108 @@
+package check
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// JSONElementFailure describes the failed checks for a single element of an array.
+type JSONElementFailure struct {
+	// Path is the path to the element.
+	Path string
+	// Index is the index of the element within the array.
+	Index int
+	// Failures contains the failure of each failed check.
+	Failures CheckFailures
+}
+
+// JSONElementsFailedError is returned when the checks fail for one or more elements in a BodyJSONQueryForEachChecker.
+type JSONElementsFailedError struct {
+	// Query is the JSON query.
+	Query string
+	// Total is the total number of elements.
+	Total int
+	// Failures contains the failures of each failed element.
+	Failures []JSONElementFailure
+}
+
+// Indexes returns the index of each failed element.
+func (e *JSONElementsFailedError) Indexes() []int {
+	indexes := make([]int, len(e.Failures))
+	for i, f := range e.Failures {
+		indexes[i] = f.Index
+	}
+	return indexes
+}
+
+// Error returns an error string.
+func (e *JSONElementsFailedError) Error() string {
+	return e.render(false)
+}
+
+// ColourError returns an error string with coloured failures.
+func (e *JSONElementsFailedError) ColourError() string {
+	return e.render(true)
+}
+
+func (e *JSONElementsFailedError) render(colour bool) string {
+	lines := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		lines[i] = fmt.Sprintf("  %s:\n%s", f.Path, f.Failures.Render("    ", colour))
+	}
+	return fmt.Sprintf("%d of %d elements at %v failed %v:\n%s", len(e.Failures), e.Total, e.Query, e.Indexes(), strings.Join(lines, "\n"))
+}
+
+// BodyJSONQueryForEachChecker queries the http response body JSON using `Query` and runs `Checks` against each element of the resulting array.
+// Each element is given to the checks as a response with the same status code and headers, and a body containing the element JSON.
+// Every element is checked, even if an earlier one fails.
+type BodyJSONQueryForEachChecker struct {
+	Query  string
+	Checks []Checker
+}
+
+// Check performs the BodyJSONQueryForEach check
+func (c *BodyJSONQueryForEachChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	elements, err := queryJSONArray(body, c.Query)
+	if err != nil {
+		return err
+	}
+
+	failures := make([]JSONElementFailure, 0)
+	for i, element := range elements {
+		elementResponse := responseWithJSONElement(response, element.Raw)
+		if elementFailures := RunChecks(ctx, elementResponse, c.Checks); len(elementFailures) > 0 {
+			failures = append(failures, JSONElementFailure{
+				Path:     jsonElementPath(c.Query, i, ""),
+				Index:    i,
+				Failures: elementFailures,
+			})
+		}
+	}
+
+	if len(failures) > 0 {
+		return &JSONElementsFailedError{
+			Query:    c.Query,
+			Total:    len(elements),
+			Failures: failures,
+		}
+	}
+
+	return nil
+}
+
+// responseWithJSONElement returns a copy of the given response with the body replaced by the given JSON.
+func responseWithJSONElement(response *http.Response, raw string) *http.Response {
+	elementResponse := *response
+	elementResponse.Body = ioutil.NopCloser(bytes.NewBufferString(raw))
+	elementResponse.ContentLength = int64(len(raw))
+	return &elementResponse
+}
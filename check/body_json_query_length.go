@@ -0,0 +1,57 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// UnexpectedJSONQueryLengthError is returned when a check fails.
+type UnexpectedJSONQueryLengthError struct {
+	// Query is the JSON query.
+	Query string
+	// Expected is a description of the expected length.
+	Expected string
+	// Actual is the actual length.
+	Actual int
+}
+
+// Error returns an error string.
+func (e *UnexpectedJSONQueryLengthError) Error() string {
+	return fmt.Sprintf("unexpected length at %v: expected %v, got %v", e.Query, e.Expected, e.Actual)
+}
+
+// BodyJSONQueryLengthChecker queries the http response body JSON using `Query` and ensures the resulting array has the expected length.
+// Each of `Value`, `Min` and `Max` are optional.
+type BodyJSONQueryLengthChecker struct {
+	Query string
+	Value *int
+	Min   *int
+	Max   *int
+}
+
+// Check performs the BodyJSONQueryLength check
+func (c *BodyJSONQueryLengthChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	elements, err := queryJSONArray(body, c.Query)
+	if err != nil {
+		return err
+	}
+
+	got := len(elements)
+
+	expected, ok := countBounds{Value: c.Value, Min: c.Min, Max: c.Max}.check(got)
+	if ok {
+		return nil
+	}
+
+	return &UnexpectedJSONQueryLengthError{
+		Query:    c.Query,
+		Expected: expected,
+		Actual:   got,
+	}
+}
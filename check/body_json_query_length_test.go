@@ -0,0 +1,68 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"testing"
+)
+
+func TestBodyJSONQueryLengthChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	body := `{"items": [1, 2, 3], "empty": [], "other": "abc"}`
+
+	tests := [...]struct {
+		desc        string
+		checker     *check.BodyJSONQueryLengthChecker
+		expectedErr string
+	}{
+		{desc: "exact", checker: &check.BodyJSONQueryLengthChecker{Query: "items", Value: intPtr(3)}},
+		{desc: "exact empty", checker: &check.BodyJSONQueryLengthChecker{Query: "empty", Value: intPtr(0)}},
+		{
+			desc:        "exact mismatch",
+			checker:     &check.BodyJSONQueryLengthChecker{Query: "items", Value: intPtr(2)},
+			expectedErr: "unexpected length at items: expected 2, got 3",
+		},
+		{desc: "min", checker: &check.BodyJSONQueryLengthChecker{Query: "items", Min: intPtr(3)}},
+		{
+			desc:        "below min",
+			checker:     &check.BodyJSONQueryLengthChecker{Query: "empty", Min: intPtr(1)},
+			expectedErr: "unexpected length at empty: expected at least 1, got 0",
+		},
+		{desc: "max", checker: &check.BodyJSONQueryLengthChecker{Query: "items", Max: intPtr(3)}},
+		{
+			desc:        "above max",
+			checker:     &check.BodyJSONQueryLengthChecker{Query: "items", Max: intPtr(2)},
+			expectedErr: "unexpected length at items: expected at most 2, got 3",
+		},
+		{desc: "min and max", checker: &check.BodyJSONQueryLengthChecker{Query: "items", Min: intPtr(1), Max: intPtr(5)}},
+		{
+			desc:        "non-array",
+			checker:     &check.BodyJSONQueryLengthChecker{Query: "other", Min: intPtr(0)},
+			expectedErr: "unexpected json type at other: expected array, got string",
+		},
+		{
+			desc:        "missing",
+			checker:     &check.BodyJSONQueryLengthChecker{Query: "missing", Value: intPtr(0)},
+			expectedErr: "value at missing is missing",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.checker.Check(context.Background(), responseWithBody(body))
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			} else if err == nil {
+				t.Errorf("expected error but got none")
+			} else if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
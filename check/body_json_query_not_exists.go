@@ -0,0 +1,106 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"github.com/tidwall/gjson"
+	"net/http"
+	"strings"
+)
+
+// JSONQueryValueExistsError is returned when a check fails.
+type JSONQueryValueExistsError struct {
+	// Query is the JSON query.
+	Query string
+	// Actual is the value that was found.
+	Actual interface{}
+}
+
+// Error returns an error string.
+func (e *JSONQueryValueExistsError) Error() string {
+	return fmt.Sprintf("value at %v exists: got %v", e.Query, canonicalJSON(e.Actual))
+}
+
+// BodyJSONQueryNotExistsChecker queries the http response body JSON using `Query` and ensures no value exists there.
+// If `Query` contains wildcards such as `users.#.password`, the check ensures the value is absent from every element.
+type BodyJSONQueryNotExistsChecker struct {
+	Query string
+}
+
+// Check performs the BodyJSONQueryNotExists check
+func (c *BodyJSONQueryNotExistsChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	r := gjson.ParseBytes(body).Get(c.Query)
+
+	if !r.Exists() || isEmptyWildcardResult(r, countJSONQueryWildcards(c.Query)) {
+		return nil
+	}
+
+	return &JSONQueryValueExistsError{
+		Query:  c.Query,
+		Actual: r.Value(),
+	}
+}
+
+// isEmptyWildcardResult returns true if the result of a query containing the given number of wildcards did not match any values.
+// Each wildcard wraps the matched values in an array, so nothing was matched if every array at that depth is empty.
+func isEmptyWildcardResult(r gjson.Result, wildcards int) bool {
+	if wildcards == 0 || !r.IsArray() {
+		return false
+	}
+	for _, element := range r.Array() {
+		if wildcards == 1 || !isEmptyWildcardResult(element, wildcards-1) {
+			return false
+		}
+	}
+	return true
+}
+
+// countJSONQueryWildcards returns the number of path components in the given gjson query that match multiple values.
+// These are `#` when followed by another component, and queries of the form `#(...)#`.
+func countJSONQueryWildcards(query string) int {
+	components := make([]string, 0)
+	var current strings.Builder
+	depth := 0
+	inString := false
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case ch == '\\' && i+1 < len(query):
+			current.WriteByte(ch)
+			i++
+			ch = query[i]
+		case inString:
+			if ch == '"' {
+				inString = false
+			}
+		case ch == '"':
+			inString = true
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case (ch == '.' || ch == '|') && depth == 0:
+			components = append(components, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteByte(ch)
+	}
+	components = append(components, current.String())
+
+	count := 0
+	for i, component := range components {
+		switch {
+		case component == "#" && i < len(components)-1:
+			count++
+		case strings.HasPrefix(component, "#(") && strings.HasSuffix(component, ")#"):
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,76 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"testing"
+)
+
+func TestBodyJSONQueryNotExistsChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	body := `{
+		"error": null,
+		"users": [{"name": "a", "roles": [{"id": 1}]}, {"name": "b", "password": "secret", "roles": [{"id": 2, "secret": true}]}],
+		"admins": [{"name": "c", "roles": []}],
+		"empty": []
+	}`
+
+	tests := [...]struct {
+		query       string
+		expectedErr string
+	}{
+		{query: "data"},
+		{query: "users.0.password"},
+		{query: "admins.#.password"},
+		{query: "admins.#.roles.#.secret"},
+		{query: "users.#(name==\"a\")#.password"},
+		{query: "users.#(name==\"z\")"},
+		{
+			query:       "error",
+			expectedErr: "value at error exists: got null",
+		},
+		{
+			query:       "empty",
+			expectedErr: "value at empty exists: got []",
+		},
+		{
+			query:       "users.#.password",
+			expectedErr: "value at users.#.password exists: got [\"secret\"]",
+		},
+		{
+			query:       "users.#.roles.#.secret",
+			expectedErr: "value at users.#.roles.#.secret exists: got [[],[true]]",
+		},
+		{
+			query:       "users.#(name==\"b\")#.password",
+			expectedErr: "value at users.#(name==\"b\")#.password exists: got [\"secret\"]",
+		},
+		{
+			query:       "users.#",
+			expectedErr: "value at users.# exists: got 2",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.query, func(t *testing.T) {
+			t.Parallel()
+
+			err := (&check.BodyJSONQueryNotExistsChecker{Query: tc.query}).Check(context.Background(), responseWithBody(body))
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("expected error but got none")
+				return
+			}
+			if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
@@ -55,16 +55,21 @@ func (c *BodyJSONQueryRegexMatchChecker) Check(ctx context.Context, response *ht
 		}
 	}
 
-	if c.DataIDs != nil {
-		values := c.Regexp.FindStringSubmatch(r.String())
-		for i, dataID := range c.DataIDs {
-			if len(values) > i {
-				if err := ContextWithOptionalDataID(ctx, dataID, values[i]); err != nil {
-					return err
-				}
+	return contextWithRegexMatches(ctx, c.Regexp, r.String(), c.DataIDs)
+}
+
+// contextWithRegexMatches stores the values of the regex groups matched in `str` under the data ids in `dataIDs`, keyed by group index.
+func contextWithRegexMatches(ctx context.Context, r *regexp.Regexp, str string, dataIDs map[int]string) error {
+	if dataIDs == nil {
+		return nil
+	}
+	values := r.FindStringSubmatch(str)
+	for i, dataID := range dataIDs {
+		if len(values) > i {
+			if err := ContextWithOptionalDataID(ctx, dataID, values[i]); err != nil {
+				return err
 			}
 		}
 	}
-
 	return nil
 }
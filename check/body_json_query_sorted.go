@@ -0,0 +1,125 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// SortDirection defines the order in which values are expected to be sorted.
+type SortDirection string
+
+const (
+	// SortAscending expects values to be sorted smallest first.
+	SortAscending SortDirection = "asc"
+	// SortDescending expects values to be sorted largest first.
+	SortDescending SortDirection = "desc"
+)
+
+// JSONQueryNotSortedError is returned when a check fails.
+type JSONQueryNotSortedError struct {
+	// Query is the JSON query.
+	Query string
+	// By is the path within each element that the elements are sorted by.
+	By string
+	// Direction is the expected sort direction.
+	Direction SortDirection
+	// Index is the index of the first element that is out of order.
+	Index int
+	// Previous is the value of the element before Index.
+	Previous interface{}
+	// Actual is the value of the element at Index.
+	Actual interface{}
+}
+
+// Error returns an error string.
+func (e *JSONQueryNotSortedError) Error() string {
+	at := e.Query
+	if e.By != "" {
+		at = fmt.Sprintf("%s (by %s)", e.Query, e.By)
+	}
+	return fmt.Sprintf("unexpected order at %v: expected %s order, but index %d has %v after %v", at, e.Direction, e.Index, e.Actual, e.Previous)
+}
+
+// BodyJSONQuerySortedChecker queries the http response body JSON using `Query` and ensures that the resulting array is sorted in `Direction`.
+// If `By` is not empty, the elements are sorted by the value found at `By` within each element.
+// Numbers are compared numerically and strings are compared lexically.
+type BodyJSONQuerySortedChecker struct {
+	Query     string
+	By        string
+	Direction SortDirection
+}
+
+// Check performs the BodyJSONQuerySorted check
+func (c *BodyJSONQuerySortedChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	elements, err := queryJSONArray(body, c.Query)
+	if err != nil {
+		return err
+	}
+
+	direction := c.Direction
+	if direction == "" {
+		direction = SortAscending
+	}
+
+	var mode CompareMode
+	var previous interface{}
+
+	for i, element := range elements {
+		r := jsonElementValue(element, c.By)
+		if !r.Exists() {
+			return &JSONQueryValueMissingError{
+				Query: jsonElementPath(c.Query, i, c.By),
+			}
+		}
+
+		got := r.Value()
+		valueMode := CompareModeString
+		if jsonType(got) == JSONTypeNumber {
+			valueMode = CompareModeNumber
+		} else if jsonType(got) != JSONTypeString {
+			return &UnexpectedJSONTypeError{
+				Query:    jsonElementPath(c.Query, i, c.By),
+				Expected: "string or number",
+				Actual:   jsonType(got),
+			}
+		}
+
+		if i == 0 {
+			mode = valueMode
+			previous = got
+			continue
+		}
+
+		if valueMode != mode {
+			return &UnexpectedJSONTypeError{
+				Query:    jsonElementPath(c.Query, i, c.By),
+				Expected: string(mode),
+				Actual:   jsonType(got),
+			}
+		}
+
+		res, err := compareValues(mode, previous, got)
+		if err != nil {
+			return err
+		}
+		if (direction == SortAscending && res > 0) || (direction == SortDescending && res < 0) {
+			return &JSONQueryNotSortedError{
+				Query:     c.Query,
+				By:        c.By,
+				Direction: direction,
+				Index:     i,
+				Previous:  previous,
+				Actual:    got,
+			}
+		}
+		previous = got
+	}
+
+	return nil
+}
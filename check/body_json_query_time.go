@@ -0,0 +1,210 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"github.com/tidwall/gjson"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// TimeOperator defines how a time is compared to the reference time.
+type TimeOperator string
+
+const (
+	// TimeBefore ensures the time is before the reference time.
+	TimeBefore TimeOperator = "before"
+	// TimeAfter ensures the time is after the reference time.
+	TimeAfter TimeOperator = "after"
+	// TimeWithin ensures the time is within a duration either side of the reference time.
+	TimeWithin TimeOperator = "within"
+)
+
+const (
+	// TimeFormatRFC3339 parses RFC 3339 strings such as `2020-01-02T15:04:05Z`.
+	TimeFormatRFC3339 = "rfc3339"
+	// TimeFormatUnix parses the number of seconds since the unix epoch.
+	TimeFormatUnix = "unix"
+	// TimeFormatUnixMilli parses the number of milliseconds since the unix epoch.
+	TimeFormatUnixMilli = "unixMilli"
+)
+
+// InvalidTimeError is returned when a value cannot be parsed as a time.
+type InvalidTimeError struct {
+	// Query is the JSON query or data ID the value was found at.
+	Query string
+	// Format is the format used to parse the value.
+	Format string
+	// Value is the value that could not be parsed.
+	Value interface{}
+}
+
+// Error returns an error string.
+func (e *InvalidTimeError) Error() string {
+	return fmt.Sprintf("could not parse value at %v as %v time: got %v", e.Query, e.Format, e.Value)
+}
+
+// UnexpectedJSONQueryTimeError is returned when a check fails.
+type UnexpectedJSONQueryTimeError struct {
+	// Query is the JSON query.
+	Query string
+	// Expected is a description of the expected time.
+	Expected string
+	// Actual is the actual time.
+	Actual time.Time
+}
+
+// Error returns an error string.
+func (e *UnexpectedJSONQueryTimeError) Error() string {
+	return fmt.Sprintf("unexpected time at %v: expected %v, got %v", e.Query, e.Expected, e.Actual.Format(time.RFC3339Nano))
+}
+
+// BodyJSONQueryTimeChecker queries the http response body JSON using `Query`, parses the value as a time using `Format` and compares it to a reference time using `Operator`.
+// `Format` is one of TimeFormatRFC3339, TimeFormatUnix, TimeFormatUnixMilli or a Go time layout. It defaults to TimeFormatRFC3339.
+// The reference time is the current time, or the time stored under `ReferenceDataID` if it is not empty. The reference time is parsed using the same format.
+// `Within` is used by the within operator.
+type BodyJSONQueryTimeChecker struct {
+	Query           string
+	Format          string
+	Operator        TimeOperator
+	ReferenceDataID string
+	Within          time.Duration
+	DataID          string
+}
+
+// Check performs the BodyJSONQueryTime check
+func (c *BodyJSONQueryTimeChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	r := gjson.ParseBytes(body).Get(c.Query)
+	if !r.Exists() {
+		return &JSONQueryValueMissingError{
+			Query: c.Query,
+		}
+	}
+
+	actual, err := parseTime(c.format(), r.Value())
+	if err != nil {
+		return &InvalidTimeError{
+			Query:  c.Query,
+			Format: c.format(),
+			Value:  r.Value(),
+		}
+	}
+
+	reference, referenceName, err := c.reference(ctx)
+	if err != nil {
+		return err
+	}
+
+	var ok bool
+	var expected string
+	switch c.Operator {
+	case TimeBefore:
+		ok = actual.Before(reference)
+		expected = fmt.Sprintf("a time before %s", referenceName)
+	case TimeAfter:
+		ok = actual.After(reference)
+		expected = fmt.Sprintf("a time after %s", referenceName)
+	case TimeWithin:
+		diff := actual.Sub(reference)
+		if diff < 0 {
+			diff = -diff
+		}
+		ok = diff <= c.Within
+		expected = fmt.Sprintf("a time within %s of %s", c.Within, referenceName)
+	default:
+		return fmt.Errorf("unhandled time operator `%s`", c.Operator)
+	}
+
+	if !ok {
+		return &UnexpectedJSONQueryTimeError{
+			Query:    c.Query,
+			Expected: expected,
+			Actual:   actual,
+		}
+	}
+
+	return ContextWithOptionalDataID(ctx, c.DataID, r.Value())
+}
+
+func (c *BodyJSONQueryTimeChecker) format() string {
+	if c.Format == "" {
+		return TimeFormatRFC3339
+	}
+	return c.Format
+}
+
+// reference returns the time to compare against, along with a description of it.
+func (c *BodyJSONQueryTimeChecker) reference(ctx context.Context) (time.Time, string, error) {
+	if c.ReferenceDataID == "" {
+		t := now()
+		return t, fmt.Sprintf("now (%s)", t.Format(time.RFC3339Nano)), nil
+	}
+
+	val := DataIDFromContext(ctx, c.ReferenceDataID)
+	if val == nil {
+		return time.Time{}, "", &DataIDMissingError{
+			DataID: c.ReferenceDataID,
+		}
+	}
+	t, err := parseTime(c.format(), val)
+	if err != nil {
+		return time.Time{}, "", &InvalidTimeError{
+			Query:  "data id " + c.ReferenceDataID,
+			Format: c.format(),
+			Value:  val,
+		}
+	}
+	return t, fmt.Sprintf("%s (%s)", c.ReferenceDataID, t.Format(time.RFC3339Nano)), nil
+}
+
+// parseTime parses the given value as a time using the given format.
+func parseTime(format string, val interface{}) (time.Time, error) {
+	if t, ok := val.(time.Time); ok {
+		return t, nil
+	}
+
+	switch format {
+	case TimeFormatUnix, TimeFormatUnixMilli:
+		num, err := toUnixNumber(val)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if format == TimeFormatUnixMilli {
+			num = num / 1000
+		}
+		sec, frac := math.Modf(num)
+		return time.Unix(int64(sec), int64(frac*float64(time.Second))), nil
+	}
+
+	str, ok := val.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("expected a string, got %T", val)
+	}
+	layout := format
+	if format == TimeFormatRFC3339 {
+		layout = time.RFC3339Nano
+	}
+	return time.Parse(layout, str)
+}
+
+// toUnixNumber returns the given number, or numeric string, as a float64.
+func toUnixNumber(val interface{}) (float64, error) {
+	switch v := val.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("expected a number, got %T", val)
+}
@@ -0,0 +1,91 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"testing"
+	"time"
+)
+
+// TestBodyJSONQueryTimeChecker_Check is not parallel because it overrides the current time.
+func TestBodyJSONQueryTimeChecker_Check(t *testing.T) {
+	defer check.SetNow(time.Date(2020, 6, 1, 12, 5, 0, 0, time.UTC))()
+
+	body := `{"created":"2020-06-01T12:00:00Z","updated":"2020-06-01T12:04:30.5+01:00","unix":1591012800,"unixMilli":"1591012800500","date":"01/06/2020","bad":"yesterday"}`
+
+	tests := [...]struct {
+		desc        string
+		checker     *check.BodyJSONQueryTimeChecker
+		expectedErr string
+	}{
+		{
+			desc:    "before now",
+			checker: &check.BodyJSONQueryTimeChecker{Query: "created", Operator: check.TimeBefore},
+		},
+		{
+			desc:        "after now",
+			checker:     &check.BodyJSONQueryTimeChecker{Query: "created", Operator: check.TimeAfter},
+			expectedErr: "unexpected time at created: expected a time after now (2020-06-01T12:05:00Z), got 2020-06-01T12:00:00Z",
+		},
+		{
+			desc:    "within now",
+			checker: &check.BodyJSONQueryTimeChecker{Query: "created", Operator: check.TimeWithin, Within: 5 * time.Minute},
+		},
+		{
+			desc:        "not within now",
+			checker:     &check.BodyJSONQueryTimeChecker{Query: "created", Operator: check.TimeWithin, Within: time.Minute},
+			expectedErr: "unexpected time at created: expected a time within 1m0s of now (2020-06-01T12:05:00Z), got 2020-06-01T12:00:00Z",
+		},
+		{
+			desc:    "before data id",
+			checker: &check.BodyJSONQueryTimeChecker{Query: "updated", Operator: check.TimeBefore, ReferenceDataID: "created"},
+		},
+		{
+			desc:    "unix",
+			checker: &check.BodyJSONQueryTimeChecker{Query: "unix", Format: check.TimeFormatUnix, Operator: check.TimeWithin, Within: 5 * time.Minute},
+		},
+		{
+			desc:    "unix milli",
+			checker: &check.BodyJSONQueryTimeChecker{Query: "unixMilli", Format: check.TimeFormatUnixMilli, Operator: check.TimeAfter, ReferenceDataID: "unix"},
+		},
+		{
+			desc:    "layout",
+			checker: &check.BodyJSONQueryTimeChecker{Query: "date", Format: "02/01/2006", Operator: check.TimeBefore},
+		},
+		{
+			desc:        "invalid",
+			checker:     &check.BodyJSONQueryTimeChecker{Query: "bad", Operator: check.TimeBefore},
+			expectedErr: "could not parse value at bad as rfc3339 time: got yesterday",
+		},
+		{
+			desc:        "missing data id",
+			checker:     &check.BodyJSONQueryTimeChecker{Query: "created", Operator: check.TimeBefore, ReferenceDataID: "nope"},
+			expectedErr: "data id `nope` has not been stored",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := check.ContextWithData(context.Background(), map[string]interface{}{
+				"created": "2020-06-01T11:10:00Z",
+				"unix":    float64(1591012800),
+			})
+
+			err := tc.checker.Check(ctx, responseWithBody(body))
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("expected error but got none")
+				return
+			}
+			if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"github.com/tidwall/gjson"
+	"math"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// JSON string formats that can be checked by BodyJSONQueryTypeChecker.
+const (
+	JSONFormatUUID     = "uuid"
+	JSONFormatEmail    = "email"
+	JSONFormatDateTime = "date-time"
+	JSONFormatDate     = "date"
+	JSONFormatURI      = "uri"
+	JSONFormatIPv4     = "ipv4"
+	JSONFormatIPv6     = "ipv6"
+)
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// jsonFormatValidators contains a validation func for each known JSON string format.
+var jsonFormatValidators = map[string]func(string) bool{
+	JSONFormatUUID:  uuidRegexp.MatchString,
+	JSONFormatEmail: validEmail,
+	JSONFormatDateTime: func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil
+	},
+	JSONFormatDate: func(s string) bool {
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	},
+	JSONFormatURI: func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	},
+	JSONFormatIPv4: func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	},
+	JSONFormatIPv6: func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && strings.Contains(s, ":")
+	},
+}
+
+// validEmail returns true if the given string is a plain email address with a fully qualified domain, such as `a@b.com`.
+func validEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s {
+		return false
+	}
+	at := strings.LastIndex(s, "@")
+	if at < 1 {
+		return false
+	}
+	labels := strings.Split(s[at+1:], ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidJSONFormat returns true if the given format can be checked.
+func ValidJSONFormat(format string) bool {
+	_, ok := jsonFormatValidators[format]
+	return ok
+}
+
+// UnexpectedJSONFormatError is returned when a check fails.
+type UnexpectedJSONFormatError struct {
+	// Query is the JSON query.
+	Query string
+	// Format is the expected format.
+	Format string
+	// Actual is the actual value.
+	Actual string
+}
+
+// Error returns an error string.
+func (e *UnexpectedJSONFormatError) Error() string {
+	return fmt.Sprintf("unexpected value at %v: expected %v format, got %v", e.Query, e.Format, e.Actual)
+}
+
+// BodyJSONQueryTypeChecker queries the http response body JSON using `Query` and ensures the value is one of the JSON types in `Types`.
+// If `Format` is not empty, the value must be a string in the given format.
+type BodyJSONQueryTypeChecker struct {
+	Query  string
+	Types  []string
+	Format string
+	DataID string
+}
+
+// Check performs the BodyJSONQueryType check
+func (c *BodyJSONQueryTypeChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	j := gjson.ParseBytes(body)
+
+	r := j.Get(c.Query)
+
+	if !r.Exists() {
+		return &JSONQueryValueMissingError{
+			Query: c.Query,
+		}
+	}
+
+	if len(c.Types) > 0 && !matchesJSONType(r, c.Types) {
+		return &UnexpectedJSONTypeError{
+			Query:    c.Query,
+			Expected: strings.Join(c.Types, " or "),
+			Actual:   jsonType(r.Value()),
+		}
+	}
+
+	if c.Format != "" && r.Type != gjson.String {
+		return &UnexpectedJSONTypeError{
+			Query:    c.Query,
+			Expected: JSONTypeString,
+			Actual:   jsonType(r.Value()),
+		}
+	}
+
+	if c.Format != "" && r.Type == gjson.String {
+		validator, ok := jsonFormatValidators[c.Format]
+		if !ok {
+			return fmt.Errorf("unhandled format `%s`", c.Format)
+		}
+		if !validator(r.String()) {
+			return &UnexpectedJSONFormatError{
+				Query:  c.Query,
+				Format: c.Format,
+				Actual: r.String(),
+			}
+		}
+	}
+
+	return ContextWithOptionalDataID(ctx, c.DataID, r.Value())
+}
+
+// matchesJSONType returns true if the given result is one of the given JSON types.
+func matchesJSONType(r gjson.Result, types []string) bool {
+	actual := jsonType(r.Value())
+	for _, t := range types {
+		if t == actual {
+			return true
+		}
+		if t == JSONTypeInteger && actual == JSONTypeNumber && math.Trunc(r.Num) == r.Num {
+			return true
+		}
+	}
+	return false
+}
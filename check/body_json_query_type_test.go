@@ -0,0 +1,173 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"testing"
+)
+
+func TestBodyJSONQueryTypeChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	body := `{
+		"string": "abc",
+		"integer": 123,
+		"float": 1.5,
+		"boolean": true,
+		"object": {},
+		"array": [],
+		"null": null,
+		"uuid": "123e4567-e89b-12d3-a456-426614174000",
+		"email": "tom@example.com",
+		"dateTime": "2020-06-01T12:00:00.123Z",
+		"date": "2020-06-01",
+		"uri": "https://example.com/users?id=1",
+		"ipv4": "127.0.0.1",
+		"ipv6": "::1"
+	}`
+
+	tests := [...]struct {
+		desc        string
+		checker     *check.BodyJSONQueryTypeChecker
+		expectedErr string
+	}{
+		{desc: "string", checker: &check.BodyJSONQueryTypeChecker{Query: "string", Types: []string{check.JSONTypeString}}},
+		{desc: "number", checker: &check.BodyJSONQueryTypeChecker{Query: "float", Types: []string{check.JSONTypeNumber}}},
+		{desc: "integer", checker: &check.BodyJSONQueryTypeChecker{Query: "integer", Types: []string{check.JSONTypeInteger}}},
+		{desc: "boolean", checker: &check.BodyJSONQueryTypeChecker{Query: "boolean", Types: []string{check.JSONTypeBoolean}}},
+		{desc: "object", checker: &check.BodyJSONQueryTypeChecker{Query: "object", Types: []string{check.JSONTypeObject}}},
+		{desc: "array", checker: &check.BodyJSONQueryTypeChecker{Query: "array", Types: []string{check.JSONTypeArray}}},
+		{desc: "null", checker: &check.BodyJSONQueryTypeChecker{Query: "null", Types: []string{check.JSONTypeNull}}},
+		{desc: "multiple types", checker: &check.BodyJSONQueryTypeChecker{Query: "null", Types: []string{check.JSONTypeString, check.JSONTypeNull}}},
+		{
+			desc:        "wrong type",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "string", Types: []string{check.JSONTypeNumber, check.JSONTypeNull}},
+			expectedErr: "unexpected json type at string: expected number or null, got string",
+		},
+		{
+			desc:        "float is not an integer",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "float", Types: []string{check.JSONTypeInteger}},
+			expectedErr: "unexpected json type at float: expected integer, got number",
+		},
+		{
+			desc:        "missing",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "missing", Types: []string{check.JSONTypeString}},
+			expectedErr: "value at missing is missing",
+		},
+		{desc: "uuid", checker: &check.BodyJSONQueryTypeChecker{Query: "uuid", Format: check.JSONFormatUUID}},
+		{desc: "email", checker: &check.BodyJSONQueryTypeChecker{Query: "email", Format: check.JSONFormatEmail}},
+		{desc: "date-time", checker: &check.BodyJSONQueryTypeChecker{Query: "dateTime", Format: check.JSONFormatDateTime}},
+		{desc: "date", checker: &check.BodyJSONQueryTypeChecker{Query: "date", Format: check.JSONFormatDate}},
+		{desc: "uri", checker: &check.BodyJSONQueryTypeChecker{Query: "uri", Format: check.JSONFormatURI}},
+		{desc: "ipv4", checker: &check.BodyJSONQueryTypeChecker{Query: "ipv4", Format: check.JSONFormatIPv4}},
+		{desc: "ipv6", checker: &check.BodyJSONQueryTypeChecker{Query: "ipv6", Format: check.JSONFormatIPv6}},
+		{
+			desc:        "invalid uuid",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "string", Format: check.JSONFormatUUID},
+			expectedErr: "unexpected value at string: expected uuid format, got abc",
+		},
+		{
+			desc:        "invalid email",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "string", Format: check.JSONFormatEmail},
+			expectedErr: "unexpected value at string: expected email format, got abc",
+		},
+		{
+			desc:        "invalid date-time",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "date", Format: check.JSONFormatDateTime},
+			expectedErr: "unexpected value at date: expected date-time format, got 2020-06-01",
+		},
+		{
+			desc:        "invalid date",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "dateTime", Format: check.JSONFormatDate},
+			expectedErr: "unexpected value at dateTime: expected date format, got 2020-06-01T12:00:00.123Z",
+		},
+		{
+			desc:        "invalid uri",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "email", Format: check.JSONFormatURI},
+			expectedErr: "unexpected value at email: expected uri format, got tom@example.com",
+		},
+		{
+			desc:        "invalid ipv4",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "ipv6", Format: check.JSONFormatIPv4},
+			expectedErr: "unexpected value at ipv6: expected ipv4 format, got ::1",
+		},
+		{
+			desc:        "invalid ipv6",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "ipv4", Format: check.JSONFormatIPv6},
+			expectedErr: "unexpected value at ipv4: expected ipv6 format, got 127.0.0.1",
+		},
+		{
+			desc:        "format on non-string",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "integer", Format: check.JSONFormatUUID},
+			expectedErr: "unexpected json type at integer: expected string, got number",
+		},
+		{
+			desc:        "format with null type",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "null", Types: []string{check.JSONTypeString, check.JSONTypeNull}, Format: check.JSONFormatUUID},
+			expectedErr: "unexpected json type at null: expected string, got null",
+		},
+		{
+			desc:        "format with number type",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "integer", Types: []string{check.JSONTypeNumber}, Format: check.JSONFormatUUID},
+			expectedErr: "unexpected json type at integer: expected string, got number",
+		},
+		{
+			desc:        "format with disallowed null",
+			checker:     &check.BodyJSONQueryTypeChecker{Query: "null", Types: []string{check.JSONTypeString}, Format: check.JSONFormatUUID},
+			expectedErr: "unexpected json type at null: expected string, got null",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.checker.Check(context.Background(), responseWithBody(body))
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			} else if err == nil {
+				t.Errorf("expected error but got none")
+			} else if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
+
+func TestBodyJSONQueryTypeChecker_Check_Email(t *testing.T) {
+	t.Parallel()
+
+	tests := [...]struct {
+		email string
+		valid bool
+	}{
+		{email: "tom@example.com", valid: true},
+		{email: "tom.wright+test@mail.example.co.uk", valid: true},
+		{email: "a@b"},
+		{email: "a@b."},
+		{email: "a@.com"},
+		{email: "a@-b.com"},
+		{email: "@example.com"},
+		{email: "Tom <tom@example.com>"},
+		{email: "tom"},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.email, func(t *testing.T) {
+			t.Parallel()
+
+			checker := &check.BodyJSONQueryTypeChecker{Query: "email", Format: check.JSONFormatEmail}
+			err := checker.Check(context.Background(), responseWithBody(`{"email": "`+tc.email+`"}`))
+			if tc.valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !tc.valid && err == nil {
+				t.Errorf("expected error but got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// JSONQueryDuplicateValueError is returned when a check fails.
+type JSONQueryDuplicateValueError struct {
+	// Query is the JSON query.
+	Query string
+	// Path is the path within each element that should be unique.
+	Path string
+	// Value is the duplicated value.
+	Value interface{}
+	// Indexes contains the indexes of the elements containing the duplicated value.
+	Indexes []int
+}
+
+// Error returns an error string.
+func (e *JSONQueryDuplicateValueError) Error() string {
+	at := e.Query
+	if e.Path != "" {
+		at = fmt.Sprintf("%s (by %s)", e.Query, e.Path)
+	}
+	return fmt.Sprintf("duplicate value at %v: %v found at indexes %v", at, e.Value, e.Indexes)
+}
+
+// BodyJSONQueryUniqueChecker queries the http response body JSON using `Query` and ensures that each element in the resulting array is unique.
+// If `Path` is not empty, uniqueness is determined by the value found at `Path` within each element.
+type BodyJSONQueryUniqueChecker struct {
+	Query string
+	Path  string
+}
+
+// Check performs the BodyJSONQueryUnique check
+func (c *BodyJSONQueryUniqueChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	elements, err := queryJSONArray(body, c.Query)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string][]int, len(elements))
+	order := make([]string, 0, len(elements))
+	values := make(map[string]interface{}, len(elements))
+
+	for i, element := range elements {
+		r := jsonElementValue(element, c.Path)
+		if !r.Exists() {
+			return &JSONQueryValueMissingError{
+				Query: jsonElementPath(c.Query, i, c.Path),
+			}
+		}
+		key := canonicalJSON(r.Value())
+		if _, ok := seen[key]; !ok {
+			order = append(order, key)
+			values[key] = r.Value()
+		}
+		seen[key] = append(seen[key], i)
+	}
+
+	for _, key := range order {
+		if indexes := seen[key]; len(indexes) > 1 {
+			return &JSONQueryDuplicateValueError{
+				Query:   c.Query,
+				Path:    c.Path,
+				Value:   values[key],
+				Indexes: indexes,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package check
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// BodyUnexpectedValueError is returned when a check fails.
+type BodyUnexpectedValueError struct {
+	// Value is the value that should not be contained in the body.
+	Value string
+	// Index is the byte offset at which the value was found.
+	Index int
+}
+
+// Error returns an error string.
+func (e *BodyUnexpectedValueError) Error() string {
+	return fmt.Sprintf("body contains %q at index %d", e.Value, e.Index)
+}
+
+// BodyNotContainsChecker is used to validate the http response body does not contain `Value`.
+// `Value` must not be empty, since every body contains an empty string.
+// If `ResolveData` is true, `Value` may be a data reference.
+type BodyNotContainsChecker struct {
+	Value       string
+	ResolveData bool
+}
+
+// Check performs the BodyNotContains check
+func (c *BodyNotContainsChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	value, err := resolveOptionalDataReferenceString(ctx, c.ResolveData, c.Value)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		return fmt.Errorf("value must not be empty")
+	}
+
+	if i := bytes.Index(body, []byte(value)); i >= 0 {
+		return &BodyUnexpectedValueError{
+			Value: value,
+			Index: i,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// UnexpectedBodyRegexValueError is returned when a check fails.
+type UnexpectedBodyRegexValueError struct {
+	// Pattern is the regex pattern.
+	Pattern string
+}
+
+// Error returns an error string.
+func (e *UnexpectedBodyRegexValueError) Error() string {
+	return fmt.Sprintf("body does not match pattern %v", e.Pattern)
+}
+
+// BodyRegexMatchChecker is used to validate the http response body matches the regex pattern in `Regexp`
+type BodyRegexMatchChecker struct {
+	Regexp  *regexp.Regexp
+	DataIDs map[int]string
+}
+
+// Check performs the BodyRegexMatch check
+func (c *BodyRegexMatchChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	if !c.Regexp.Match(body) {
+		return &UnexpectedBodyRegexValueError{
+			Pattern: c.Regexp.String(),
+		}
+	}
+
+	return contextWithRegexMatches(ctx, c.Regexp, string(body), c.DataIDs)
+}
@@ -0,0 +1,67 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// UnexpectedXMLQueryValueError is returned when a check fails.
+type UnexpectedXMLQueryValueError struct {
+	// Query is the XPath query.
+	Query string
+	// Expected is the expected value.
+	Expected string
+	// Actual is the actual value.
+	Actual string
+}
+
+// Error returns an error string.
+func (e *UnexpectedXMLQueryValueError) Error() string {
+	return fmt.Sprintf("unexpected value at %v: expected %v, got %v", e.Query, e.Expected, e.Actual)
+}
+
+// BodyXMLQueryEqualChecker queries the http response body XML using the XPath query in `Query` and ensures the value is equal to `Value`.
+// `Namespaces` maps namespace prefixes used in `Query` to namespace URIs.
+// If `ResolveData` is true, `Value` may be a data reference.
+type BodyXMLQueryEqualChecker struct {
+	Query       string
+	Namespaces  map[string]string
+	Value       string
+	ResolveData bool
+	DataID      string
+}
+
+// Check performs the BodyXMLQueryEqual check
+func (c *BodyXMLQueryEqualChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	got, ok, err := evaluateXMLQuery(body, c.Query, c.Namespaces)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return &XMLQueryValueMissingError{
+			Query: c.Query,
+		}
+	}
+
+	expected, err := resolveOptionalDataReferenceString(ctx, c.ResolveData, c.Value)
+	if err != nil {
+		return err
+	}
+
+	if got != expected {
+		return &UnexpectedXMLQueryValueError{
+			Query:    c.Query,
+			Expected: expected,
+			Actual:   got,
+		}
+	}
+
+	return ContextWithOptionalDataID(ctx, c.DataID, got)
+}
@@ -0,0 +1,36 @@
+package check
+
+import (
+	"context"
+	"net/http"
+)
+
+// BodyXMLQueryExistsChecker queries the http response body XML using the XPath query in `Query` and ensures a value exists there.
+// Queries that return a boolean, number or string, such as `count(//item)`, only pass if the result is true, non-zero or non-empty.
+// `Namespaces` maps namespace prefixes used in `Query` to namespace URIs.
+type BodyXMLQueryExistsChecker struct {
+	Query      string
+	Namespaces map[string]string
+	DataID     string
+}
+
+// Check performs the BodyXMLQueryExists check
+func (c *BodyXMLQueryExistsChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	got, ok, err := evaluateXMLQueryExists(body, c.Query, c.Namespaces)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return &XMLQueryValueMissingError{
+			Query: c.Query,
+		}
+	}
+
+	return ContextWithOptionalDataID(ctx, c.DataID, got)
+}
@@ -0,0 +1,61 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// UnexpectedXMLQueryRegexValueError is returned when a check fails.
+type UnexpectedXMLQueryRegexValueError struct {
+	// Pattern is the regex pattern.
+	Pattern string
+	// Query is the XPath query.
+	Query string
+	// Actual is the actual value.
+	Actual string
+}
+
+// Error returns an error string.
+func (e *UnexpectedXMLQueryRegexValueError) Error() string {
+	return fmt.Sprintf("unexpected value at %v: does not match pattern %v: got %v", e.Query, e.Pattern, e.Actual)
+}
+
+// BodyXMLQueryRegexMatchChecker queries the http response body XML using the XPath query in `Query` and ensures that it matches the regex pattern in `Regexp`.
+// `Namespaces` maps namespace prefixes used in `Query` to namespace URIs.
+type BodyXMLQueryRegexMatchChecker struct {
+	Query      string
+	Namespaces map[string]string
+	Regexp     *regexp.Regexp
+	DataIDs    map[int]string
+}
+
+// Check performs the BodyXMLQueryRegexMatch check
+func (c *BodyXMLQueryRegexMatchChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	got, ok, err := evaluateXMLQuery(body, c.Query, c.Namespaces)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return &XMLQueryValueMissingError{
+			Query: c.Query,
+		}
+	}
+
+	if !c.Regexp.MatchString(got) {
+		return &UnexpectedXMLQueryRegexValueError{
+			Pattern: c.Regexp.String(),
+			Query:   c.Query,
+			Actual:  got,
+		}
+	}
+
+	return contextWithRegexMatches(ctx, c.Regexp, got, c.DataIDs)
+}
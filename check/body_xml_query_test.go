@@ -0,0 +1,114 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"regexp"
+	"testing"
+)
+
+func TestBodyXMLQueryCheckers(t *testing.T) {
+	t.Parallel()
+
+	body := `<?xml version="1.0"?>
+<p:order xmlns:p="urn:partner" id="123">
+	<p:status>shipped</p:status>
+	<p:item sku="A1">Widget</p:item>
+	<p:item sku="B2">Gadget</p:item>
+</p:order>`
+
+	ns := map[string]string{"x": "urn:partner"}
+
+	tests := [...]struct {
+		desc        string
+		checker     check.Checker
+		expectedErr bool
+		dataID      string
+		dataValue   interface{}
+	}{
+		{
+			desc:      "exists with namespace",
+			checker:   &check.BodyXMLQueryExistsChecker{Query: "/x:order/x:status", Namespaces: ns, DataID: "status"},
+			dataID:    "status",
+			dataValue: "shipped",
+		},
+		{
+			desc:        "does not exist",
+			checker:     &check.BodyXMLQueryExistsChecker{Query: "/x:order/x:missing", Namespaces: ns},
+			expectedErr: true,
+		},
+		{
+			desc:        "false boolean does not exist",
+			checker:     &check.BodyXMLQueryExistsChecker{Query: "boolean(/x:order/x:missing)", Namespaces: ns},
+			expectedErr: true,
+		},
+		{
+			desc:        "zero count does not exist",
+			checker:     &check.BodyXMLQueryExistsChecker{Query: "count(/x:order/x:missing)", Namespaces: ns},
+			expectedErr: true,
+		},
+		{
+			desc:        "empty string does not exist",
+			checker:     &check.BodyXMLQueryExistsChecker{Query: "string(/x:order/x:missing)", Namespaces: ns},
+			expectedErr: true,
+		},
+		{
+			desc:      "non-zero count exists",
+			checker:   &check.BodyXMLQueryExistsChecker{Query: "count(/x:order/x:item)", Namespaces: ns, DataID: "count"},
+			dataID:    "count",
+			dataValue: "2",
+		},
+		{
+			desc:    "true boolean exists",
+			checker: &check.BodyXMLQueryExistsChecker{Query: "boolean(/x:order/x:item)", Namespaces: ns},
+		},
+		{
+			desc:      "equal attribute",
+			checker:   &check.BodyXMLQueryEqualChecker{Query: "/x:order/@id", Namespaces: ns, Value: "123", DataID: "id"},
+			dataID:    "id",
+			dataValue: "123",
+		},
+		{
+			desc:    "equal function",
+			checker: &check.BodyXMLQueryEqualChecker{Query: "count(//x:item)", Namespaces: ns, Value: "2"},
+		},
+		{
+			desc:        "not equal",
+			checker:     &check.BodyXMLQueryEqualChecker{Query: "//x:item[@sku='B2']", Namespaces: ns, Value: "Widget"},
+			expectedErr: true,
+		},
+		{
+			desc:      "regex match",
+			checker:   &check.BodyXMLQueryRegexMatchChecker{Query: "//x:item[2]", Namespaces: ns, Regexp: regexp.MustCompile(`^(G)adget$`), DataIDs: map[int]string{1: "letter"}},
+			dataID:    "letter",
+			dataValue: "G",
+		},
+		{
+			desc:        "regex does not match",
+			checker:     &check.BodyXMLQueryRegexMatchChecker{Query: "//x:item[1]", Namespaces: ns, Regexp: regexp.MustCompile(`^Gadget$`)},
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := check.ContextWithData(context.Background(), make(map[string]interface{}))
+
+			err := tc.checker.Check(ctx, responseWithBody(body))
+			if tc.expectedErr && err == nil {
+				t.Errorf("expected error but got none")
+			}
+			if !tc.expectedErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if tc.dataID != "" {
+				if exp, got := tc.dataValue, check.DataIDFromContext(ctx, tc.dataID); exp != got {
+					t.Errorf("expected data value `%v`, got `%v`", exp, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"github.com/tidwall/gjson"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Capturer is used to extract a value from a response so that it can be stored in the context data.
+type Capturer interface {
+	Capture(ctx context.Context, response *http.Response) (interface{}, error)
+}
+
+// Capture stores the value extracted by `Capturer` under `DataID`.
+type Capture struct {
+	DataID   string
+	Capturer Capturer
+}
+
+// CaptureError is returned when a value cannot be captured.
+type CaptureError struct {
+	// DataID is the ID the value would have been stored under.
+	DataID string
+	// Err is the reason the value could not be captured.
+	Err error
+}
+
+// Error returns an error string.
+func (e *CaptureError) Error() string {
+	return fmt.Sprintf("could not capture `%s`: %s", e.DataID, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CaptureError) Unwrap() error {
+	return e.Err
+}
+
+// CaptureAll runs each of the given captures against the response and stores the values in the context data.
+func CaptureAll(ctx context.Context, response *http.Response, captures []Capture) error {
+	for _, c := range captures {
+		val, err := c.Capturer.Capture(ctx, response)
+		if err != nil {
+			return &CaptureError{
+				DataID: c.DataID,
+				Err:    err,
+			}
+		}
+		if err := ContextWithDataID(ctx, c.DataID, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StatusCodeCapturer captures the response status code.
+// The status code is stored as a float64 so that it matches numbers parsed from JSON.
+type StatusCodeCapturer struct {
+}
+
+// Capture returns the status code
+func (c *StatusCodeCapturer) Capture(ctx context.Context, response *http.Response) (interface{}, error) {
+	return float64(response.StatusCode), nil
+}
+
+// HeaderCapturer captures the value of the response header `Name`. Multiple values are joined with a comma.
+type HeaderCapturer struct {
+	Name string
+}
+
+// Capture returns the header value
+func (c *HeaderCapturer) Capture(ctx context.Context, response *http.Response) (interface{}, error) {
+	values, ok := response.Header[http.CanonicalHeaderKey(c.Name)]
+	if !ok {
+		return nil, &HeaderMissingError{
+			Name: c.Name,
+		}
+	}
+	return strings.Join(values, ", "), nil
+}
+
+// CookieCapturer captures the value of the cookie `Name` set by the response.
+type CookieCapturer struct {
+	Name string
+}
+
+// Capture returns the cookie value
+func (c *CookieCapturer) Capture(ctx context.Context, response *http.Response) (interface{}, error) {
+	var value *string
+	for _, cookie := range response.Cookies() {
+		if cookie.Name == c.Name {
+			value = &cookie.Value
+		}
+	}
+	if value == nil {
+		return nil, &CookieMissingError{
+			Name: c.Name,
+		}
+	}
+	return *value, nil
+}
+
+// BodyJSONQueryCapturer captures the value found by querying the response body JSON using `Query`.
+type BodyJSONQueryCapturer struct {
+	Query string
+}
+
+// Capture returns the queried value
+func (c *BodyJSONQueryCapturer) Capture(ctx context.Context, response *http.Response) (interface{}, error) {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return nil, err
+	}
+	r := gjson.GetBytes(body, c.Query)
+	if !r.Exists() {
+		return nil, &JSONQueryValueMissingError{
+			Query: c.Query,
+		}
+	}
+	return r.Value(), nil
+}
+
+// BodyRegexCapturer captures the given `Group` of the first match of `Regexp` in the response body.
+type BodyRegexCapturer struct {
+	Regexp *regexp.Regexp
+	Group  int
+}
+
+// Capture returns the matched group
+func (c *BodyRegexCapturer) Capture(ctx context.Context, response *http.Response) (interface{}, error) {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return nil, err
+	}
+	values := c.Regexp.FindStringSubmatch(string(body))
+	if values == nil {
+		return nil, &UnexpectedBodyRegexValueError{
+			Pattern: c.Regexp.String(),
+		}
+	}
+	if c.Group < 0 || c.Group >= len(values) {
+		return nil, fmt.Errorf("pattern %v does not have group %d", c.Regexp, c.Group)
+	}
+	return values[c.Group], nil
+}
+
+// BodyCapturer captures the whole response body as a string.
+type BodyCapturer struct {
+}
+
+// Capture returns the body
+func (c *BodyCapturer) Capture(ctx context.Context, response *http.Response) (interface{}, error) {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return nil, err
+	}
+	return string(body), nil
+}
@@ -0,0 +1,126 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// NoChecksPassedError is returned when none of the checks in an AnyOfChecker pass.
+type NoChecksPassedError struct {
+	// Failures contains the failure of each check.
+	Failures CheckFailures
+}
+
+// Error returns an error string.
+func (e *NoChecksPassedError) Error() string {
+	return e.render(false)
+}
+
+// ColourError returns an error string with coloured failures.
+func (e *NoChecksPassedError) ColourError() string {
+	return e.render(true)
+}
+
+func (e *NoChecksPassedError) render(colour bool) string {
+	return fmt.Sprintf("none of the %d checks passed:\n%s", len(e.Failures), e.Failures.Render("  ", colour))
+}
+
+// ChecksFailedError is returned when one or more of the checks in an AllOfChecker fail.
+type ChecksFailedError struct {
+	// Total is the total number of checks.
+	Total int
+	// Failures contains the failure of each failed check.
+	Failures CheckFailures
+}
+
+// Error returns an error string.
+func (e *ChecksFailedError) Error() string {
+	return e.render(false)
+}
+
+// ColourError returns an error string with coloured failures.
+func (e *ChecksFailedError) ColourError() string {
+	return e.render(true)
+}
+
+func (e *ChecksFailedError) render(colour bool) string {
+	return fmt.Sprintf("%d of %d checks failed:\n%s", len(e.Failures), e.Total, e.Failures.Render("  ", colour))
+}
+
+// UnexpectedPassError is returned when the checks in a NotChecker pass.
+type UnexpectedPassError struct {
+	// Types contains the type of each check.
+	Types []string
+}
+
+// Error returns an error string.
+func (e *UnexpectedPassError) Error() string {
+	return fmt.Sprintf("expected checks to fail but they passed: %v", e.Types)
+}
+
+// RunChecks runs every one of the given checks, even if an earlier one fails, and returns the failures.
+func RunChecks(ctx context.Context, response *http.Response, checks []Checker) CheckFailures {
+	failures := make(CheckFailures, 0)
+	for i, c := range checks {
+		if err := c.Check(ctx, response); err != nil {
+			failures = append(failures, NewCheckFailure(i, c, err))
+		}
+	}
+	return failures
+}
+
+// AnyOfChecker passes if at least one of `Checks` passes.
+type AnyOfChecker struct {
+	Checks []Checker
+}
+
+// Check performs the AnyOf check
+func (c *AnyOfChecker) Check(ctx context.Context, response *http.Response) error {
+	failures := make(CheckFailures, 0, len(c.Checks))
+	for i, checker := range c.Checks {
+		err := checker.Check(ctx, response)
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, NewCheckFailure(i, checker, err))
+	}
+	return &NoChecksPassedError{
+		Failures: failures,
+	}
+}
+
+// AllOfChecker passes if every one of `Checks` passes. Every check is run, even if an earlier one fails.
+type AllOfChecker struct {
+	Checks []Checker
+}
+
+// Check performs the AllOf check
+func (c *AllOfChecker) Check(ctx context.Context, response *http.Response) error {
+	if failures := RunChecks(ctx, response, c.Checks); len(failures) > 0 {
+		return &ChecksFailedError{
+			Total:    len(c.Checks),
+			Failures: failures,
+		}
+	}
+	return nil
+}
+
+// NotChecker passes if any of `Checks` fail.
+type NotChecker struct {
+	Checks []Checker
+}
+
+// Check performs the Not check
+func (c *NotChecker) Check(ctx context.Context, response *http.Response) error {
+	if failures := RunChecks(ctx, response, c.Checks); len(failures) > 0 {
+		return nil
+	}
+	types := make([]string, len(c.Checks))
+	for i, checker := range c.Checks {
+		types[i] = fmt.Sprintf("%T", checker)
+	}
+	return &UnexpectedPassError{
+		Types: types,
+	}
+}
@@ -0,0 +1,147 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestCombinatorCheckers(t *testing.T) {
+	t.Parallel()
+
+	status := func(code int) check.Checker {
+		return &check.StatusCodeEqualChecker{Value: code}
+	}
+
+	tests := [...]struct {
+		desc        string
+		checker     check.Checker
+		expectedErr string
+	}{
+		{
+			desc:    "any of passes",
+			checker: &check.AnyOfChecker{Checks: []check.Checker{status(204), status(200)}},
+		},
+		{
+			desc:    "any of fails",
+			checker: &check.AnyOfChecker{Checks: []check.Checker{status(201), status(204)}},
+			expectedErr: "none of the 2 checks passed:\n" +
+				"  [0] `*check.StatusCodeEqualChecker`: unexpected status code: expected 201, got 200\n" +
+				"  [1] `*check.StatusCodeEqualChecker`: unexpected status code: expected 204, got 200",
+		},
+		{
+			desc:    "all of passes",
+			checker: &check.AllOfChecker{Checks: []check.Checker{status(200), &check.BodyContainsChecker{Value: "ok"}}},
+		},
+		{
+			desc:    "all of fails",
+			checker: &check.AllOfChecker{Checks: []check.Checker{status(200), &check.BodyContainsChecker{Value: "nope"}, status(500)}},
+			expectedErr: "2 of 3 checks failed:\n" +
+				"  [1] `*check.BodyContainsChecker`: body does not contain \"nope\"\n" +
+				"  [2] `*check.StatusCodeEqualChecker`: unexpected status code: expected 500, got 200",
+		},
+		{
+			desc:    "not passes",
+			checker: &check.NotChecker{Checks: []check.Checker{status(500)}},
+		},
+		{
+			desc:        "not fails",
+			checker:     &check.NotChecker{Checks: []check.Checker{status(200)}},
+			expectedErr: "expected checks to fail but they passed: [*check.StatusCodeEqualChecker]",
+		},
+		{
+			desc: "nested",
+			checker: &check.AnyOfChecker{Checks: []check.Checker{
+				status(204),
+				&check.AllOfChecker{Checks: []check.Checker{status(200), &check.NotChecker{Checks: []check.Checker{&check.BodyContainsChecker{Value: "ok"}}}}},
+			}},
+			expectedErr: "none of the 2 checks passed:\n" +
+				"  [0] `*check.StatusCodeEqualChecker`: unexpected status code: expected 204, got 200\n" +
+				"  [1] `*check.AllOfChecker`: 1 of 2 checks failed:\n" +
+				"      [1] `*check.NotChecker`: expected checks to fail but they passed: [*check.BodyContainsChecker]",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			response := responseWithBody("ok")
+			response.StatusCode = http.StatusOK
+
+			err := tc.checker.Check(context.Background(), response)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("expected error but got none")
+				return
+			}
+			if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
+
+func TestBodyJSONQueryForEachChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	body := `{"orders": [{"status": "paid", "total": 10}, {"status": "unknown", "total": 5}, {"status": "paid", "total": -1}]}`
+
+	statusCheck := &check.BodyJSONQueryRegexMatchChecker{Query: "status", Regexp: regexp.MustCompile(`^(paid|pending)$`)}
+	totalCheck := &check.BodyJSONQueryCompareChecker{Query: "total", Operator: check.CompareGreaterThan, Value: 0}
+
+	tests := [...]struct {
+		desc        string
+		checker     check.Checker
+		expectedErr string
+	}{
+		{
+			desc:    "all elements pass",
+			checker: &check.BodyJSONQueryForEachChecker{Query: "orders", Checks: []check.Checker{&check.BodyJSONQueryExistsChecker{Query: "total"}}},
+		},
+		{
+			desc:    "elements fail",
+			checker: &check.BodyJSONQueryForEachChecker{Query: "orders", Checks: []check.Checker{statusCheck, totalCheck}},
+			expectedErr: "2 of 3 elements at orders failed [1 2]:\n" +
+				"  orders.1:\n" +
+				"    [0] `*check.BodyJSONQueryRegexMatchChecker`: unexpected value at status: does not match pattern ^(paid|pending)$: got unknown\n" +
+				"  orders.2:\n" +
+				"    [1] `*check.BodyJSONQueryCompareChecker`: unexpected value at total: expected a value > 0, got -1",
+		},
+		{
+			desc:        "query is not an array",
+			checker:     &check.BodyJSONQueryForEachChecker{Query: "orders.0", Checks: []check.Checker{statusCheck}},
+			expectedErr: "unexpected json type at orders.0: expected array, got object",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.checker.Check(context.Background(), responseWithBody(body))
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("expected error but got none")
+				return
+			}
+			if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package check
+
+import (
+	"context"
+)
+
+const (
+	compressionCtxKey ctxKey = "compression"
+)
+
+// Compression contains information about how a response body was encoded.
+type Compression struct {
+	// Encoding is the value of the Content-Encoding header, or empty if the body was not encoded.
+	Encoding string
+	// CompressedSize is the size of the body in bytes as it was received, or -1 if it is unknown because the http client decoded it.
+	CompressedSize int
+	// DecompressedSize is the size of the decoded body in bytes.
+	DecompressedSize int
+}
+
+// ContextWithCompression embeds the given response compression information in the context.
+func ContextWithCompression(ctx context.Context, compression Compression) context.Context {
+	return context.WithValue(ctx, compressionCtxKey, compression)
+}
+
+// CompressionFromContext returns the response compression information from the context.
+func CompressionFromContext(ctx context.Context) (Compression, bool) {
+	compression, ok := ctx.Value(compressionCtxKey).(Compression)
+	return compression, ok
+}
@@ -0,0 +1,84 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// UnexpectedContentEncodingError is returned when a check fails.
+type UnexpectedContentEncodingError struct {
+	// Expected is the expected content encoding.
+	Expected string
+	// Actual is the actual content encoding.
+	Actual string
+}
+
+// Error returns an error string.
+func (e *UnexpectedContentEncodingError) Error() string {
+	return fmt.Sprintf("unexpected content encoding: expected %v, got %v", e.Expected, e.Actual)
+}
+
+// UnexpectedCompressionError is returned when a check fails.
+type UnexpectedCompressionError struct {
+	// Expected is a description of the expected compression.
+	Expected string
+	// Compression is the actual compression.
+	Compression Compression
+}
+
+// Error returns an error string.
+func (e *UnexpectedCompressionError) Error() string {
+	if e.Compression.CompressedSize < 0 {
+		return fmt.Sprintf("unexpected compression: expected %v, but the compressed size is unknown as the http client decoded the body: set the Accept-Encoding request header to prevent this", e.Expected)
+	}
+	return fmt.Sprintf("unexpected compression: expected %v, got %d bytes decompressed to %d bytes", e.Expected, e.Compression.CompressedSize, e.Compression.DecompressedSize)
+}
+
+// ContentEncodingChecker checks the content encoding of the response, using the compression information stored in the context.
+// `Encoding` is the expected Content-Encoding, where `identity` means the body was not encoded. If empty, any encoding is accepted.
+// If `MaxCompressedSize` is not zero, the body as received must be no larger than it.
+// If `MinRatio` is not zero, the decompressed size divided by the compressed size must be at least it.
+type ContentEncodingChecker struct {
+	Encoding          string
+	MaxCompressedSize int
+	MinRatio          float64
+}
+
+// Check performs the ContentEncoding check
+func (c *ContentEncodingChecker) Check(ctx context.Context, response *http.Response) error {
+	compression, ok := CompressionFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("compression information is not available")
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(compression.Encoding))
+	if encoding == "" {
+		encoding = "identity"
+	}
+	if c.Encoding != "" && c.Encoding != encoding {
+		return &UnexpectedContentEncodingError{
+			Expected: c.Encoding,
+			Actual:   encoding,
+		}
+	}
+
+	if c.MaxCompressedSize > 0 && (compression.CompressedSize < 0 || compression.CompressedSize > c.MaxCompressedSize) {
+		return &UnexpectedCompressionError{
+			Expected:    fmt.Sprintf("at most %d bytes compressed", c.MaxCompressedSize),
+			Compression: compression,
+		}
+	}
+
+	if c.MinRatio > 0 {
+		if compression.CompressedSize <= 0 || float64(compression.DecompressedSize)/float64(compression.CompressedSize) < c.MinRatio {
+			return &UnexpectedCompressionError{
+				Expected:    fmt.Sprintf("a compression ratio of at least %v", c.MinRatio),
+				Compression: compression,
+			}
+		}
+	}
+
+	return nil
+}
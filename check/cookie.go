@@ -0,0 +1,162 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// SameSite values that can be checked by CookieChecker.
+const (
+	CookieSameSiteDefault = "default"
+	CookieSameSiteLax     = "lax"
+	CookieSameSiteStrict  = "strict"
+	CookieSameSiteNone    = "none"
+)
+
+// CookieMissingError is returned when a check fails.
+type CookieMissingError struct {
+	// Name is the name of the cookie.
+	Name string
+}
+
+// Error returns an error string.
+func (e *CookieMissingError) Error() string {
+	return fmt.Sprintf("cookie %v was not set", e.Name)
+}
+
+// UnexpectedCookieAttributeError is returned when a check fails.
+type UnexpectedCookieAttributeError struct {
+	// Name is the name of the cookie.
+	Name string
+	// Attribute is the name of the cookie attribute.
+	Attribute string
+	// Expected is a description of the expected value.
+	Expected string
+	// Actual is the actual value.
+	Actual string
+}
+
+// Error returns an error string.
+func (e *UnexpectedCookieAttributeError) Error() string {
+	return fmt.Sprintf("unexpected %v for cookie %v: expected %v, got %v", e.Attribute, e.Name, e.Expected, e.Actual)
+}
+
+// CookieChecker ensures that the http response sets the cookie `Name` using a `Set-Cookie` header.
+// All other fields are optional and are only checked if set.
+// `MinLifetime` and `MaxLifetime` are compared against the time until the cookie expires, using `Max-Age` if present or `Expires` otherwise.
+type CookieChecker struct {
+	Name        string
+	Value       *regexp.Regexp
+	HTTPOnly    *bool
+	Secure      *bool
+	SameSite    string
+	Path        string
+	Session     *bool
+	MinLifetime time.Duration
+	MaxLifetime time.Duration
+	DataID      string
+}
+
+// Check performs the Cookie check
+func (c *CookieChecker) Check(ctx context.Context, response *http.Response) error {
+	var cookie *http.Cookie
+	for _, responseCookie := range response.Cookies() {
+		if responseCookie.Name == c.Name {
+			cookie = responseCookie
+		}
+	}
+
+	if cookie == nil {
+		return &CookieMissingError{
+			Name: c.Name,
+		}
+	}
+
+	if c.Value != nil && !c.Value.MatchString(cookie.Value) {
+		return c.attributeErr("value", fmt.Sprintf("match for pattern %s", c.Value), cookie.Value)
+	}
+
+	if c.HTTPOnly != nil && *c.HTTPOnly != cookie.HttpOnly {
+		return c.attributeErr("HttpOnly", strconv.FormatBool(*c.HTTPOnly), strconv.FormatBool(cookie.HttpOnly))
+	}
+
+	if c.Secure != nil && *c.Secure != cookie.Secure {
+		return c.attributeErr("Secure", strconv.FormatBool(*c.Secure), strconv.FormatBool(cookie.Secure))
+	}
+
+	if got := cookieSameSite(cookie.SameSite); c.SameSite != "" && c.SameSite != got {
+		return c.attributeErr("SameSite", c.SameSite, got)
+	}
+
+	if c.Path != "" && c.Path != cookie.Path {
+		return c.attributeErr("Path", c.Path, cookie.Path)
+	}
+
+	lifetime, session := cookieLifetime(cookie)
+
+	if c.Session != nil && *c.Session != session {
+		expected := "a session cookie"
+		if !*c.Session {
+			expected = "an expiry"
+		}
+		return c.attributeErr("expiry", expected, fmtCookieLifetime(lifetime, session))
+	}
+
+	if c.MinLifetime > 0 && (session || lifetime < c.MinLifetime) {
+		return c.attributeErr("expiry", fmt.Sprintf("at least %s", c.MinLifetime), fmtCookieLifetime(lifetime, session))
+	}
+
+	if c.MaxLifetime > 0 && (session || lifetime > c.MaxLifetime) {
+		return c.attributeErr("expiry", fmt.Sprintf("at most %s", c.MaxLifetime), fmtCookieLifetime(lifetime, session))
+	}
+
+	return ContextWithOptionalDataID(ctx, c.DataID, cookie.Value)
+}
+
+func (c *CookieChecker) attributeErr(attribute string, expected string, actual string) error {
+	return &UnexpectedCookieAttributeError{
+		Name:      c.Name,
+		Attribute: attribute,
+		Expected:  expected,
+		Actual:    actual,
+	}
+}
+
+// cookieSameSite returns the name of the given SameSite mode. An empty string is returned if the attribute was not set.
+func cookieSameSite(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteDefaultMode:
+		return CookieSameSiteDefault
+	case http.SameSiteLaxMode:
+		return CookieSameSiteLax
+	case http.SameSiteStrictMode:
+		return CookieSameSiteStrict
+	case http.SameSiteNoneMode:
+		return CookieSameSiteNone
+	}
+	return ""
+}
+
+// cookieLifetime returns the time until the cookie expires, and whether or not it is a session cookie.
+func cookieLifetime(cookie *http.Cookie) (time.Duration, bool) {
+	switch {
+	case cookie.MaxAge > 0:
+		return time.Duration(cookie.MaxAge) * time.Second, false
+	case cookie.MaxAge < 0:
+		return 0, false
+	case !cookie.Expires.IsZero():
+		return time.Until(cookie.Expires), false
+	}
+	return 0, true
+}
+
+func fmtCookieLifetime(lifetime time.Duration, session bool) string {
+	if session {
+		return "a session cookie"
+	}
+	return fmt.Sprintf("expiry in %s", lifetime.Round(time.Second))
+}
@@ -0,0 +1,167 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func responseWithCookies(cookies ...string) *http.Response {
+	r := responseWithBody("")
+	for _, cookie := range cookies {
+		r.Header.Add("Set-Cookie", cookie)
+	}
+	return r
+}
+
+func TestCookieChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := [...]struct {
+		desc        string
+		cookies     []string
+		checker     *check.CookieChecker
+		expectedErr string
+	}{
+		{
+			desc:    "set",
+			cookies: []string{"session=abc123"},
+			checker: &check.CookieChecker{Name: "session"},
+		},
+		{
+			desc:        "missing",
+			cookies:     []string{"other=abc123"},
+			checker:     &check.CookieChecker{Name: "session"},
+			expectedErr: "cookie session was not set",
+		},
+		{
+			desc:        "no cookies",
+			checker:     &check.CookieChecker{Name: "session"},
+			expectedErr: "cookie session was not set",
+		},
+		{
+			desc:    "value matches",
+			cookies: []string{"session=abc123"},
+			checker: &check.CookieChecker{Name: "session", Value: regexp.MustCompile(`^[a-z]+\d+$`)},
+		},
+		{
+			desc:        "value mismatch",
+			cookies:     []string{"session=abc123"},
+			checker:     &check.CookieChecker{Name: "session", Value: regexp.MustCompile(`^\d+$`)},
+			expectedErr: `unexpected value for cookie session: expected match for pattern ^\d+$, got abc123`,
+		},
+		{
+			desc:    "secure and http only",
+			cookies: []string{"session=abc123; Secure; HttpOnly"},
+			checker: &check.CookieChecker{Name: "session", Secure: boolPtr(true), HTTPOnly: boolPtr(true)},
+		},
+		{
+			desc:        "not secure",
+			cookies:     []string{"session=abc123; HttpOnly"},
+			checker:     &check.CookieChecker{Name: "session", Secure: boolPtr(true)},
+			expectedErr: "unexpected Secure for cookie session: expected true, got false",
+		},
+		{
+			desc:        "unexpectedly secure",
+			cookies:     []string{"session=abc123; Secure"},
+			checker:     &check.CookieChecker{Name: "session", Secure: boolPtr(false)},
+			expectedErr: "unexpected Secure for cookie session: expected false, got true",
+		},
+		{
+			desc:        "not http only",
+			cookies:     []string{"session=abc123; Secure"},
+			checker:     &check.CookieChecker{Name: "session", HTTPOnly: boolPtr(true)},
+			expectedErr: "unexpected HttpOnly for cookie session: expected true, got false",
+		},
+		{
+			desc:    "same site",
+			cookies: []string{"session=abc123; SameSite=Strict"},
+			checker: &check.CookieChecker{Name: "session", SameSite: check.CookieSameSiteStrict},
+		},
+		{
+			desc:        "same site mismatch",
+			cookies:     []string{"session=abc123; SameSite=Lax"},
+			checker:     &check.CookieChecker{Name: "session", SameSite: check.CookieSameSiteStrict},
+			expectedErr: "unexpected SameSite for cookie session: expected strict, got lax",
+		},
+		{
+			desc:    "same site unrecognised",
+			cookies: []string{"session=abc123; SameSite=Unknown"},
+			checker: &check.CookieChecker{Name: "session", SameSite: check.CookieSameSiteDefault},
+		},
+		{
+			desc:    "path",
+			cookies: []string{"session=abc123; Path=/api"},
+			checker: &check.CookieChecker{Name: "session", Path: "/api"},
+		},
+		{
+			desc:        "path mismatch",
+			cookies:     []string{"session=abc123; Path=/"},
+			checker:     &check.CookieChecker{Name: "session", Path: "/api"},
+			expectedErr: "unexpected Path for cookie session: expected /api, got /",
+		},
+		{
+			desc:        "session cookie",
+			cookies:     []string{"session=abc123; Max-Age=3600"},
+			checker:     &check.CookieChecker{Name: "session", Session: boolPtr(true)},
+			expectedErr: "unexpected expiry for cookie session: expected a session cookie, got expiry in 1h0m0s",
+		},
+		{
+			desc:    "lifetime",
+			cookies: []string{"session=abc123; Max-Age=3600"},
+			checker: &check.CookieChecker{Name: "session", MinLifetime: time.Minute, MaxLifetime: 2 * time.Hour},
+		},
+		{
+			desc:        "lifetime below min",
+			cookies:     []string{"session=abc123; Max-Age=60"},
+			checker:     &check.CookieChecker{Name: "session", MinLifetime: time.Hour},
+			expectedErr: "unexpected expiry for cookie session: expected at least 1h0m0s, got expiry in 1m0s",
+		},
+		{
+			desc:        "session cookie has no lifetime",
+			cookies:     []string{"session=abc123"},
+			checker:     &check.CookieChecker{Name: "session", MaxLifetime: time.Hour},
+			expectedErr: "unexpected expiry for cookie session: expected at most 1h0m0s, got a session cookie",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.checker.Check(context.Background(), responseWithCookies(tc.cookies...))
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			} else if err == nil {
+				t.Errorf("expected error but got none")
+			} else if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
+
+func TestCookieChecker_Check_DataID(t *testing.T) {
+	t.Parallel()
+
+	data := make(map[string]interface{})
+	ctx := check.ContextWithData(context.Background(), data)
+
+	checker := &check.CookieChecker{Name: "session", DataID: "sessionId"}
+	if err := checker.Check(ctx, responseWithCookies("session=abc123")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if exp, got := "abc123", data["sessionId"]; exp != got {
+		t.Errorf("expected data `%v`, got `%v`", exp, got)
+	}
+}
@@ -0,0 +1,157 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSError is returned when a preflight response does not allow the request as expected.
+type CORSError struct {
+	// Origin is the origin used in the preflight request.
+	Origin string
+	// Violations describes each problem with the preflight response.
+	Violations Violations
+}
+
+// Error returns an error string.
+func (e *CORSError) Error() string {
+	return fmt.Sprintf("cors preflight for origin %v failed: %s", e.Origin, e.Violations)
+}
+
+// CORSChecker sends a CORS preflight OPTIONS request to the URL of the tested request and validates the response.
+// The preflight request uses `Origin`, and requests `Method` and `Headers`.
+// The response must allow the origin, method and each of the headers.
+// If `Credentials` is not nil, Access-Control-Allow-Credentials must match it. Wildcards are not accepted when credentials are allowed.
+// If `MinMaxAge` is not zero, Access-Control-Max-Age must be at least that long.
+// If `Denied` is true, the check instead ensures that the preflight response does not allow the origin.
+// The http client is taken from the context. Cookies are not sent.
+type CORSChecker struct {
+	Origin      string
+	Method      string
+	Headers     []string
+	Credentials *bool
+	MinMaxAge   time.Duration
+	Denied      bool
+}
+
+// Check performs the CORS check
+func (c *CORSChecker) Check(ctx context.Context, response *http.Response) error {
+	if response.Request == nil || response.Request.URL == nil {
+		return fmt.Errorf("cors check requires the url of the tested request")
+	}
+
+	preflight, err := c.preflight(ctx, response.Request.URL.String())
+	if err != nil {
+		return err
+	}
+
+	var violations Violations
+
+	allowOrigin := preflight.Header.Get("Access-Control-Allow-Origin")
+	allowCredentials := preflight.Header.Get("Access-Control-Allow-Credentials") == "true"
+	originAllowed := allowOrigin == c.Origin || (allowOrigin == "*" && !allowCredentials)
+
+	if c.Denied {
+		if preflight.StatusCode >= 200 && preflight.StatusCode < 300 && originAllowed {
+			violations.Add("Access-Control-Allow-Origin: expected origin to be denied, got %q", allowOrigin)
+		}
+		if len(violations) > 0 {
+			return &CORSError{Origin: c.Origin, Violations: violations}
+		}
+		return nil
+	}
+
+	if preflight.StatusCode < 200 || preflight.StatusCode >= 300 {
+		violations.Add("status code: expected 2xx, got %d", preflight.StatusCode)
+	}
+
+	if !originAllowed {
+		violations.Add("Access-Control-Allow-Origin: expected %q, got %q", c.Origin, allowOrigin)
+	}
+
+	if c.Method != "" && !isSimpleCORSMethod(c.Method) {
+		if !corsListAllows(preflight.Header.Get("Access-Control-Allow-Methods"), c.Method, !allowCredentials, true) {
+			violations.Add("Access-Control-Allow-Methods: expected %s to be allowed, got %q", c.Method, preflight.Header.Get("Access-Control-Allow-Methods"))
+		}
+	}
+
+	for _, header := range c.Headers {
+		if !corsListAllows(preflight.Header.Get("Access-Control-Allow-Headers"), header, !allowCredentials, false) {
+			violations.Add("Access-Control-Allow-Headers: expected %s to be allowed, got %q", header, preflight.Header.Get("Access-Control-Allow-Headers"))
+		}
+	}
+
+	if c.Credentials != nil && *c.Credentials != allowCredentials {
+		violations.Add("Access-Control-Allow-Credentials: expected %t, got %q", *c.Credentials, preflight.Header.Get("Access-Control-Allow-Credentials"))
+	}
+
+	if c.MinMaxAge > 0 {
+		maxAgeStr := preflight.Header.Get("Access-Control-Max-Age")
+		maxAge, err := strconv.Atoi(maxAgeStr)
+		if err != nil || time.Duration(maxAge)*time.Second < c.MinMaxAge {
+			violations.Add("Access-Control-Max-Age: expected at least %d, got %q", int64(c.MinMaxAge/time.Second), maxAgeStr)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &CORSError{Origin: c.Origin, Violations: violations}
+	}
+
+	return nil
+}
+
+// preflight sends the preflight request to the given url.
+func (c *CORSChecker) preflight(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodOptions, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create cors preflight request: %w", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Origin", c.Origin)
+	if c.Method != "" {
+		req.Header.Set("Access-Control-Request-Method", c.Method)
+	}
+	if len(c.Headers) > 0 {
+		req.Header.Set("Access-Control-Request-Headers", strings.ToLower(strings.Join(c.Headers, ",")))
+	}
+
+	// browsers do not send credentials with preflight requests
+	httpClient := *HTTPClientFromContext(ctx)
+	httpClient.Jar = nil
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute cors preflight request: %w", err)
+	}
+	if _, err := readResponseBody(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// isSimpleCORSMethod returns true if the method is always allowed by CORS.
+func isSimpleCORSMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost:
+		return true
+	}
+	return false
+}
+
+// corsListAllows returns true if the comma separated list contains the value, or a wildcard if they are allowed.
+func corsListAllows(list string, value string, allowWildcard bool, caseSensitive bool) bool {
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "*" && allowWildcard {
+			return true
+		}
+		if item == value || (!caseSensitive && strings.EqualFold(item, value)) {
+			return true
+		}
+	}
+	return false
+}
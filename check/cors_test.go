@@ -0,0 +1,151 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCORSChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodOptions {
+			rw.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		switch origin := r.Header.Get("Origin"); origin {
+		case "https://app.example.com":
+			rw.Header().Set("Access-Control-Allow-Origin", origin)
+			rw.Header().Set("Access-Control-Allow-Methods", "GET, PUT, DELETE")
+			rw.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			rw.Header().Set("Access-Control-Allow-Credentials", "true")
+			rw.Header().Set("Access-Control-Max-Age", "600")
+		case "https://public.example.com":
+			rw.Header().Set("Access-Control-Allow-Origin", "*")
+			rw.Header().Set("Access-Control-Allow-Methods", "*")
+			rw.Header().Set("Access-Control-Allow-Headers", "*")
+		case "https://wildcard.example.com":
+			rw.Header().Set("Access-Control-Allow-Origin", "*")
+			rw.Header().Set("Access-Control-Allow-Credentials", "true")
+		default:
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	trueVal, falseVal := true, false
+
+	tests := []struct {
+		desc        string
+		checker     *check.CORSChecker
+		expectedErr string
+	}{
+		{
+			desc: "allowed",
+			checker: &check.CORSChecker{
+				Origin:      "https://app.example.com",
+				Method:      http.MethodPut,
+				Headers:     []string{"Authorization", "content-type"},
+				Credentials: &trueVal,
+				MinMaxAge:   10 * time.Minute,
+			},
+		},
+		{
+			desc: "simple method",
+			checker: &check.CORSChecker{
+				Origin: "https://app.example.com",
+				Method: http.MethodPost,
+			},
+		},
+		{
+			desc: "wildcards",
+			checker: &check.CORSChecker{
+				Origin:      "https://public.example.com",
+				Method:      http.MethodPatch,
+				Headers:     []string{"X-Custom"},
+				Credentials: &falseVal,
+			},
+		},
+		{
+			desc: "violations",
+			checker: &check.CORSChecker{
+				Origin:      "https://app.example.com",
+				Method:      http.MethodPatch,
+				Headers:     []string{"X-Custom"},
+				Credentials: &falseVal,
+				MinMaxAge:   time.Hour,
+			},
+			expectedErr: `cors preflight for origin https://app.example.com failed: 4 violation(s):
+  - Access-Control-Allow-Methods: expected PATCH to be allowed, got "GET, PUT, DELETE"
+  - Access-Control-Allow-Headers: expected X-Custom to be allowed, got "Content-Type, Authorization"
+  - Access-Control-Allow-Credentials: expected false, got "true"
+  - Access-Control-Max-Age: expected at least 3600, got "600"`,
+		},
+		{
+			desc: "wildcard origin with credentials",
+			checker: &check.CORSChecker{
+				Origin: "https://wildcard.example.com",
+			},
+			expectedErr: `cors preflight for origin https://wildcard.example.com failed: 1 violation(s):
+  - Access-Control-Allow-Origin: expected "https://wildcard.example.com", got "*"`,
+		},
+		{
+			desc: "unknown origin",
+			checker: &check.CORSChecker{
+				Origin: "https://evil.example.com",
+				Method: http.MethodDelete,
+			},
+			expectedErr: `cors preflight for origin https://evil.example.com failed: 3 violation(s):
+  - status code: expected 2xx, got 403
+  - Access-Control-Allow-Origin: expected "https://evil.example.com", got ""
+  - Access-Control-Allow-Methods: expected DELETE to be allowed, got ""`,
+		},
+		{
+			desc: "denied",
+			checker: &check.CORSChecker{
+				Origin: "https://evil.example.com",
+				Denied: true,
+			},
+		},
+		{
+			desc: "not denied",
+			checker: &check.CORSChecker{
+				Origin: "https://public.example.com",
+				Denied: true,
+			},
+			expectedErr: `cors preflight for origin https://public.example.com failed: 1 violation(s):
+  - Access-Control-Allow-Origin: expected origin to be denied, got "*"`,
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, ts.URL+"/users", nil)
+			if err != nil {
+				t.Fatalf("could not create request: %s", err)
+			}
+			response := responseWithBody("")
+			response.Request = req
+
+			ctx := check.ContextWithHTTPClient(context.Background(), ts.Client())
+
+			err = tc.checker.Check(ctx, response)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			} else if err == nil {
+				t.Errorf("expected error but got none")
+			} else if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
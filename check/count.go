@@ -0,0 +1,25 @@
+package check
+
+import (
+	"fmt"
+)
+
+// countBounds describes the expected value, minimum and maximum of a count, each of which are optional.
+type countBounds struct {
+	Value *int
+	Min   *int
+	Max   *int
+}
+
+// check returns a description of the expected count, and false, if the given count is outside of the bounds.
+func (b countBounds) check(got int) (string, bool) {
+	switch {
+	case b.Value != nil && got != *b.Value:
+		return fmt.Sprint(*b.Value), false
+	case b.Min != nil && got < *b.Min:
+		return fmt.Sprintf("at least %d", *b.Min), false
+	case b.Max != nil && got > *b.Max:
+		return fmt.Sprintf("at most %d", *b.Max), false
+	}
+	return "", true
+}
@@ -11,6 +11,17 @@ const (
 	dataCtxKey ctxKey = "ctxData"
 )
 
+// DataIDMissingError is returned when a check requires data that has not been stored.
+type DataIDMissingError struct {
+	// DataID is the ID of the data.
+	DataID string
+}
+
+// Error returns an error string.
+func (e *DataIDMissingError) Error() string {
+	return fmt.Sprintf("data id `%s` has not been stored", e.DataID)
+}
+
 // DataFromContext returns a map of check data from the context.
 func DataFromContext(ctx context.Context) map[string]interface{} {
 	val := ctx.Value(dataCtxKey)
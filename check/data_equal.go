@@ -22,15 +22,22 @@ func (e *UnexpectedDataValueError) Error() string {
 	return fmt.Sprintf("unexpected data value for %s: expected %v, got %v", e.DataID, e.Expected, e.Actual)
 }
 
-// DataEqualChecker is used to check whether or not the value stored in the context data is equal to the given value
+// DataEqualChecker is used to check whether or not the value stored in the context data is equal to the given value.
+// If `ResolveData` is true, data references within `Value` are resolved.
 type DataEqualChecker struct {
-	DataID string
-	Value  interface{}
+	DataID      string
+	Value       interface{}
+	ResolveData bool
 }
 
 // Check performs the DataEqual check
 func (c *DataEqualChecker) Check(ctx context.Context, response *http.Response) error {
-	if exp, got := c.Value, DataIDFromContext(ctx, c.DataID); !reflect.DeepEqual(exp, got) {
+	expected, err := resolveOptionalDataReferences(ctx, c.ResolveData, c.Value)
+	if err != nil {
+		return err
+	}
+
+	if exp, got := expected, DataIDFromContext(ctx, c.DataID); !reflect.DeepEqual(exp, got) {
 		return &UnexpectedDataValueError{
 			DataID:   c.DataID,
 			Expected: exp,
@@ -0,0 +1,95 @@
+package check
+
+import (
+	"context"
+	"strings"
+)
+
+// DataReferencePrefix is the prefix used to reference stored data in expected values.
+const DataReferencePrefix = "$."
+
+// EscapedDataReferencePrefix is used at the start of an expected value that should be taken literally, rather than as a data reference.
+// `$$.userId` is resolved to the literal string `$.userId`.
+const EscapedDataReferencePrefix = "$" + DataReferencePrefix
+
+// ResolveDataReferences returns val with every data reference replaced by the data stored under that ID.
+// A data reference is a string starting with DataReferencePrefix, such as `$.userId`.
+// Strings starting with EscapedDataReferencePrefix have the first `$` removed and are not resolved.
+// Strings, and the values within maps and slices, are resolved. The given value is not modified.
+func ResolveDataReferences(ctx context.Context, val interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case string:
+		if strings.HasPrefix(v, EscapedDataReferencePrefix) {
+			return v[1:], nil
+		}
+		if !strings.HasPrefix(v, DataReferencePrefix) {
+			return v, nil
+		}
+		dataID := strings.TrimPrefix(v, DataReferencePrefix)
+		data := DataFromContext(ctx)
+		if data == nil {
+			return nil, &DataIDMissingError{DataID: dataID}
+		}
+		dataVal, ok := data[dataID]
+		if !ok {
+			return nil, &DataIDMissingError{DataID: dataID}
+		}
+		return dataVal, nil
+
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			resolved, err := ResolveDataReferences(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			res[k] = resolved
+		}
+		return res, nil
+
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, item := range v {
+			resolved, err := ResolveDataReferences(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = resolved
+		}
+		return res, nil
+	}
+
+	return val, nil
+}
+
+// ResolveDataReferenceString resolves the given string as a data reference, in the same way as ResolveDataReferences.
+// Stored values that are not strings are encoded as JSON.
+func ResolveDataReferenceString(ctx context.Context, str string) (string, error) {
+	val, err := ResolveDataReferences(ctx, str)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := val.(string); ok {
+		return s, nil
+	}
+	if b, ok := val.([]byte); ok {
+		return string(b), nil
+	}
+	return canonicalJSON(val), nil
+}
+
+// resolveOptionalDataReferences resolves the data references in val if resolve is true, otherwise val is returned as is.
+func resolveOptionalDataReferences(ctx context.Context, resolve bool, val interface{}) (interface{}, error) {
+	if !resolve {
+		return val, nil
+	}
+	return ResolveDataReferences(ctx, val)
+}
+
+// resolveOptionalDataReferenceString resolves the given string as a data reference if resolve is true, otherwise str is returned as is.
+func resolveOptionalDataReferenceString(ctx context.Context, resolve bool, str string) (string, error) {
+	if !resolve {
+		return str, nil
+	}
+	return ResolveDataReferenceString(ctx, str)
+}
@@ -0,0 +1,164 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"reflect"
+	"testing"
+)
+
+func TestResolveDataReferences(t *testing.T) {
+	t.Parallel()
+
+	ctx := check.ContextWithData(context.Background(), map[string]interface{}{
+		"id":   "123",
+		"size": float64(2),
+	})
+
+	val := map[string]interface{}{
+		"id":    "$.id",
+		"sizes": []interface{}{"$.size", float64(3)},
+		"name":  "Tom",
+		"path":  "$$.id",
+	}
+
+	got, err := check.ResolveDataReferences(ctx, val)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	exp := map[string]interface{}{
+		"id":    "123",
+		"sizes": []interface{}{float64(2), float64(3)},
+		"name":  "Tom",
+		"path":  "$.id",
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if val["id"] != "$.id" {
+		t.Errorf("expected given value to be unmodified")
+	}
+
+	_, err = check.ResolveDataReferences(ctx, []interface{}{"$.nope"})
+	if exp, got := "data id `nope` has not been stored", err.Error(); exp != got {
+		t.Errorf("expected error %q, got %q", exp, got)
+	}
+}
+
+func TestCheckers_DataReferences(t *testing.T) {
+	t.Parallel()
+
+	body := `{"id":"123","user":{"id":"123","age":30},"items":[{"id":"123"}]}`
+
+	tests := [...]struct {
+		desc        string
+		checker     check.Checker
+		expectedErr string
+	}{
+		{
+			desc:    "body equal",
+			checker: &check.BodyEqualChecker{Value: "$.body", ResolveData: true},
+		},
+		{
+			desc:    "body contains number",
+			checker: &check.BodyContainsChecker{Value: "$.age", ResolveData: true},
+		},
+		{
+			desc:    "json body equal",
+			checker: &check.BodyJSONChecker{Value: map[string]interface{}{"id": "$.id", "user": "$.user", "items": []interface{}{map[string]interface{}{"id": "$.id"}}}, ResolveData: true},
+		},
+		{
+			desc:    "json query equal",
+			checker: &check.BodyJSONQueryEqualChecker{Query: "user.id", Value: "$.id", ResolveData: true},
+		},
+		{
+			desc:        "json query equal fails",
+			checker:     &check.BodyJSONQueryEqualChecker{Query: "user.age", Value: "$.otherAge", ResolveData: true},
+			expectedErr: "unexpected value at user.age: expected 31, got 30",
+		},
+		{
+			desc:    "json query compare",
+			checker: &check.BodyJSONQueryCompareChecker{Query: "user.age", Operator: check.CompareBetween, Min: "$.age", Max: "$.otherAge", ResolveData: true},
+		},
+		{
+			desc:    "json query contains",
+			checker: &check.BodyJSONQueryContainsChecker{Query: "items", Value: map[string]interface{}{"id": "$.id"}, ResolveData: true},
+		},
+		{
+			desc:    "data equal",
+			checker: &check.DataEqualChecker{DataID: "id", Value: "$.id", ResolveData: true},
+		},
+		{
+			desc:    "header equal",
+			checker: &check.HeaderEqualChecker{Name: "location", Value: "$.location", ResolveData: true},
+		},
+		{
+			desc:        "header equal fails",
+			checker:     &check.HeaderEqualChecker{Name: "Location", Value: "/users/456"},
+			expectedErr: "unexpected value for header Location: expected /users/456, got /users/123",
+		},
+		{
+			desc:        "header missing",
+			checker:     &check.HeaderEqualChecker{Name: "X-Missing", Value: "a"},
+			expectedErr: "header X-Missing was not set",
+		},
+		{
+			desc:    "escaped literal",
+			checker: &check.HeaderEqualChecker{Name: "X-Query", Value: "$$.user.id", ResolveData: true},
+		},
+		{
+			desc:        "escaped literal fails",
+			checker:     &check.BodyJSONQueryEqualChecker{Query: "id", Value: "$$.id", ResolveData: true},
+			expectedErr: "unexpected value at id: expected $.id, got 123",
+		},
+		{
+			desc:        "not resolved by default",
+			checker:     &check.BodyJSONQueryEqualChecker{Query: "id", Value: "$.id"},
+			expectedErr: "unexpected value at id: expected $.id, got 123",
+		},
+		{
+			desc:    "literal by default",
+			checker: &check.HeaderEqualChecker{Name: "X-Query", Value: "$.user.id"},
+		},
+		{
+			desc:        "missing data id",
+			checker:     &check.BodyJSONQueryEqualChecker{Query: "id", Value: "$.missing", ResolveData: true},
+			expectedErr: "data id `missing` has not been stored",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := check.ContextWithData(context.Background(), map[string]interface{}{
+				"id":       "123",
+				"age":      float64(30),
+				"otherAge": float64(31),
+				"user":     map[string]interface{}{"id": "123", "age": float64(30)},
+				"body":     body,
+				"location": "/users/123",
+			})
+
+			response := responseWithBody(body)
+			response.Header = map[string][]string{"Location": {"/users/123"}, "X-Query": {"$.user.id"}}
+
+			err := tc.checker.Check(ctx, response)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("expected error but got none")
+				return
+			}
+			if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package check
+
+import (
+	"time"
+)
+
+// SetNow makes checks use the given time as the current time, and returns a func that restores the real clock.
+// Tests that use it must not run in parallel.
+func SetNow(t time.Time) (restore func()) {
+	now = func() time.Time {
+		return t
+	}
+	return func() {
+		now = time.Now
+	}
+}
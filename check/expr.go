@@ -0,0 +1,157 @@
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/tidwall/gjson"
+	"github.com/tomwright/apitestr/expr"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ExprFalseError is returned when an expression evaluates to false.
+type ExprFalseError struct {
+	// Expression is the expression that was evaluated.
+	Expression string
+	// Operands is true if Left and Right contain the operands of a comparison.
+	Operands bool
+	// Left is the value of the left operand.
+	Left interface{}
+	// Right is the value of the right operand.
+	Right interface{}
+}
+
+// Error returns an error string.
+func (e *ExprFalseError) Error() string {
+	if !e.Operands {
+		return fmt.Sprintf("expression `%s` is false", e.Expression)
+	}
+	return fmt.Sprintf("expression `%s` is false: left is %s, right is %s", e.Expression, expr.FormatValue(e.Left), expr.FormatValue(e.Right))
+}
+
+// ExprResultTypeError is returned when an expression does not evaluate to a boolean.
+type ExprResultTypeError struct {
+	// Expression is the expression that was evaluated.
+	Expression string
+	// Actual is the value the expression evaluated to.
+	Actual interface{}
+}
+
+// Error returns an error string.
+func (e *ExprResultTypeError) Error() string {
+	return fmt.Sprintf("expression `%s` must evaluate to a boolean, got %s", e.Expression, expr.FormatValue(e.Actual))
+}
+
+// ExprChecker evaluates the expression in `Program` against the response and ensures it is true.
+// The expression has access to the following variables:
+//   - status: the response status code
+//   - headers: the response headers, keyed by canonical header name
+//   - body: the parsed JSON response body, or null if it is not JSON
+//   - text: the raw response body
+//   - data: the test data store
+//   - timings: the request timings in milliseconds: timings.firstByte and timings.total
+//
+// And the following functions:
+//   - header(name): the value of the given response header, case insensitive
+//   - query(query): the result of the given gjson query against the response body
+type ExprChecker struct {
+	Program *expr.Program
+}
+
+// Check performs the Expr check
+func (c *ExprChecker) Check(ctx context.Context, response *http.Response) error {
+	env, err := exprEnv(ctx, response)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.Program.Eval(env)
+	if err != nil {
+		return err
+	}
+
+	passed, ok := res.(bool)
+	if !ok {
+		return &ExprResultTypeError{
+			Expression: c.Program.String(),
+			Actual:     res,
+		}
+	}
+	if passed {
+		return nil
+	}
+
+	left, right, ok := c.Program.Operands(env)
+	return &ExprFalseError{
+		Expression: c.Program.String(),
+		Operands:   ok,
+		Left:       left,
+		Right:      right,
+	}
+}
+
+// exprEnv returns the expression environment for the given response.
+func exprEnv(ctx context.Context, response *http.Response) (expr.Env, error) {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsedBody interface{}
+	if err := json.Unmarshal(body, &parsedBody); err != nil {
+		parsedBody = nil
+	}
+
+	headers := make(map[string]interface{}, len(response.Header))
+	for name, values := range response.Header {
+		headers[http.CanonicalHeaderKey(name)] = strings.Join(values, ", ")
+	}
+
+	data := make(map[string]interface{})
+	for k, v := range DataFromContext(ctx) {
+		data[k] = v
+	}
+
+	env := expr.Env{
+		"status":  response.StatusCode,
+		"headers": headers,
+		"body":    parsedBody,
+		"text":    string(body),
+		"data":    data,
+		"header": expr.Func(func(args ...interface{}) (interface{}, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("header expects 1 argument(s), got %d", len(args))
+			}
+			name, ok := args[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("header expects a string")
+			}
+			values, ok := response.Header[http.CanonicalHeaderKey(name)]
+			if !ok {
+				return nil, nil
+			}
+			return strings.Join(values, ", "), nil
+		}),
+		"query": expr.Func(func(args ...interface{}) (interface{}, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("query expects 1 argument(s), got %d", len(args))
+			}
+			query, ok := args[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("query expects a string")
+			}
+			return gjson.GetBytes(body, query).Value(), nil
+		}),
+	}
+
+	if timings, ok := TimingsFromContext(ctx); ok {
+		env["timings"] = map[string]interface{}{
+			"firstByte": float64(timings.FirstByte) / float64(time.Millisecond),
+			"total":     float64(timings.Total) / float64(time.Millisecond),
+		}
+	}
+
+	return env, nil
+}
@@ -0,0 +1,78 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"github.com/tomwright/apitestr/expr"
+	"testing"
+	"time"
+)
+
+func TestExprChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := [...]struct {
+		expression  string
+		expectedErr string
+	}{
+		{expression: `status == 200 && header("content-type") == "application/json"`},
+		{expression: `headers["Content-Type"] == "application/json"`},
+		{expression: `len(body.items) == 2 && body.items[1].name == "b"`},
+		{expression: `query("items.#.name") == ["a", "b"]`},
+		{expression: `"\"a\"" in text`},
+		{expression: `data.userId == body.items[0].id`},
+		{expression: `timings.total < 1000 && timings.firstByte <= timings.total`},
+		{expression: `header("X-Missing") == null`},
+		{
+			expression:  `len(body.items) > 2`,
+			expectedErr: "expression `len(body.items) > 2` is false: left is 2, right is 2",
+		},
+		{
+			expression:  `body.items[0].name == "b" || status == 201`,
+			expectedErr: "expression `body.items[0].name == \"b\" || status == 201` is false",
+		},
+		{
+			expression:  `body.items`,
+			expectedErr: "expression `body.items` must evaluate to a boolean, got [{\"id\":1,\"name\":\"a\"},{\"id\":2,\"name\":\"b\"}]",
+		},
+		{
+			expression:  `body.items.name`,
+			expectedErr: "could not evaluate `body.items.name`: cannot access field `name` of array",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.expression, func(t *testing.T) {
+			t.Parallel()
+
+			program, err := expr.Compile(tc.expression)
+			if err != nil {
+				t.Errorf("unexpected compile error: %s", err)
+				return
+			}
+
+			response := responseWithBody(`{"items": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]}`)
+			response.StatusCode = 200
+			response.Header = map[string][]string{"Content-Type": {"application/json"}}
+
+			ctx := check.ContextWithData(context.Background(), map[string]interface{}{"userId": 1})
+			ctx = check.ContextWithTimings(ctx, check.Timings{FirstByte: 10 * time.Millisecond, Total: 20 * time.Millisecond})
+
+			err = (&check.ExprChecker{Program: program}).Check(ctx, response)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("expected error but got none")
+				return
+			}
+			if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
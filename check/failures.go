@@ -0,0 +1,70 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CheckFailure describes a single failed check.
+type CheckFailure struct {
+	// Index is the index of the check within its list of checks.
+	Index int
+	// Type is the type of the check.
+	Type string
+	// Err is the error returned by the check.
+	Err error
+}
+
+// NewCheckFailure returns a CheckFailure for the given checker.
+func NewCheckFailure(index int, checker Checker, err error) CheckFailure {
+	return CheckFailure{
+		Index: index,
+		Type:  fmt.Sprintf("%T", checker),
+		Err:   err,
+	}
+}
+
+// Error returns an error string.
+func (f CheckFailure) Error() string {
+	return f.render(false)
+}
+
+func (f CheckFailure) render(colour bool) string {
+	return fmt.Sprintf("[%d] `%s`: %s", f.Index, f.Type, indentLines(ErrorString(f.Err, colour), "  "))
+}
+
+// CheckFailures is a list of failed checks.
+type CheckFailures []CheckFailure
+
+// Render returns each failure on a new line, indented with the given prefix.
+// If colour is true, failures that implement ColourError are rendered with colours.
+func (f CheckFailures) Render(prefix string, colour bool) string {
+	lines := make([]string, len(f))
+	for i, failure := range f {
+		lines[i] = prefix + indentLines(failure.render(colour), prefix)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentLines adds the given prefix to every line after the first.
+func indentLines(str string, prefix string) string {
+	return strings.Replace(str, "\n", "\n"+prefix, -1)
+}
+
+// ErrorString returns the error string.
+// If colour is true, the first ColourError in the error chain is rendered with colours.
+func ErrorString(err error, colour bool) string {
+	if err == nil {
+		return ""
+	}
+	errStr := err.Error()
+	if !colour {
+		return errStr
+	}
+	var colourErr ColourError
+	if errors.As(err, &colourErr) {
+		errStr = strings.Replace(errStr, colourErr.Error(), colourErr.ColourError(), 1)
+	}
+	return errStr
+}
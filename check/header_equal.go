@@ -0,0 +1,69 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// HeaderMissingError is returned when a response header is not set.
+type HeaderMissingError struct {
+	// Name is the name of the header.
+	Name string
+}
+
+// Error returns an error string.
+func (e *HeaderMissingError) Error() string {
+	return fmt.Sprintf("header %v was not set", e.Name)
+}
+
+// UnexpectedHeaderValueError is returned when a check fails.
+type UnexpectedHeaderValueError struct {
+	// Name is the name of the header.
+	Name string
+	// Expected is the expected value.
+	Expected string
+	// Actual is the actual value.
+	Actual string
+}
+
+// Error returns an error string.
+func (e *UnexpectedHeaderValueError) Error() string {
+	return fmt.Sprintf("unexpected value for header %v: expected %v, got %v", e.Name, e.Expected, e.Actual)
+}
+
+// HeaderEqualChecker ensures the value of the response header `Name` is equal to `Value`. Multiple values are joined with a comma.
+// If `ResolveData` is true, `Value` may be a data reference such as `$.location`.
+type HeaderEqualChecker struct {
+	Name        string
+	Value       string
+	ResolveData bool
+	DataID      string
+}
+
+// Check performs the HeaderEqual check
+func (c *HeaderEqualChecker) Check(ctx context.Context, response *http.Response) error {
+	values, ok := response.Header[http.CanonicalHeaderKey(c.Name)]
+	if !ok {
+		return &HeaderMissingError{
+			Name: c.Name,
+		}
+	}
+
+	expected, err := resolveOptionalDataReferenceString(ctx, c.ResolveData, c.Value)
+	if err != nil {
+		return err
+	}
+
+	got := strings.Join(values, ", ")
+	if got != expected {
+		return &UnexpectedHeaderValueError{
+			Name:     c.Name,
+			Expected: expected,
+			Actual:   got,
+		}
+	}
+
+	return ContextWithOptionalDataID(ctx, c.DataID, got)
+}
@@ -0,0 +1,90 @@
+package check
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"strings"
+)
+
+// HTMLSelectorMissingError is returned when a check fails.
+type HTMLSelectorMissingError struct {
+	// Selector is the CSS selector.
+	Selector string
+}
+
+// Error returns an error string.
+func (e *HTMLSelectorMissingError) Error() string {
+	return fmt.Sprintf("no elements match %v", e.Selector)
+}
+
+// HTMLAttributeMissingError is returned when a check fails.
+type HTMLAttributeMissingError struct {
+	// Selector is the CSS selector.
+	Selector string
+	// Attribute is the name of the missing attribute.
+	Attribute string
+}
+
+// Error returns an error string.
+func (e *HTMLAttributeMissingError) Error() string {
+	return fmt.Sprintf("attribute %v is missing from %v", e.Attribute, e.Selector)
+}
+
+// CompileHTMLSelector compiles the given CSS selector.
+func CompileHTMLSelector(selector string) (cascadia.Selector, error) {
+	sel, err := cascadia.Compile(selector)
+	if err != nil {
+		return nil, fmt.Errorf("could not compile css selector `%s`: %w", selector, err)
+	}
+	return sel, nil
+}
+
+// selectHTML parses the body as HTML and returns the elements matching the given CSS selector.
+func selectHTML(body []byte, selector string) (*goquery.Selection, error) {
+	sel, err := CompileHTMLSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse html response: %w", err)
+	}
+
+	return doc.FindMatcher(sel), nil
+}
+
+// htmlSelectionValue returns the value of the first element in the selection.
+// If attribute is empty the trimmed text of the element is returned, otherwise the value of the attribute is returned.
+func htmlSelectionValue(selection *goquery.Selection, selector string, attribute string) (string, error) {
+	if selection.Length() == 0 {
+		return "", &HTMLSelectorMissingError{
+			Selector: selector,
+		}
+	}
+
+	first := selection.First()
+
+	if attribute == "" {
+		return strings.TrimSpace(first.Text()), nil
+	}
+
+	val, ok := first.Attr(attribute)
+	if !ok {
+		return "", &HTMLAttributeMissingError{
+			Selector:  selector,
+			Attribute: attribute,
+		}
+	}
+	return val, nil
+}
+
+// htmlValueDescription describes the value being checked, for use in error messages.
+func htmlValueDescription(selector string, attribute string) string {
+	if attribute == "" {
+		return selector
+	}
+	return fmt.Sprintf("%s[%s]", selector, attribute)
+}
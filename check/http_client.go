@@ -0,0 +1,23 @@
+package check
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	httpClientCtxKey ctxKey = "httpClient"
+)
+
+// ContextWithHTTPClient embeds the given http client in the context, for use by checks that send their own requests.
+func ContextWithHTTPClient(ctx context.Context, httpClient *http.Client) context.Context {
+	return context.WithValue(ctx, httpClientCtxKey, httpClient)
+}
+
+// HTTPClientFromContext returns the http client from the context, or http.DefaultClient if there is none.
+func HTTPClientFromContext(ctx context.Context) *http.Client {
+	if httpClient, ok := ctx.Value(httpClientCtxKey).(*http.Client); ok && httpClient != nil {
+		return httpClient
+	}
+	return http.DefaultClient
+}
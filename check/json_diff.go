@@ -0,0 +1,207 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DiffKind describes how a single value differs between the expected and actual JSON.
+type DiffKind string
+
+const (
+	// DiffKindMissing is used when a value is expected but not present in the actual JSON.
+	DiffKindMissing DiffKind = "missing"
+	// DiffKindExtra is used when a value is present in the actual JSON but not expected.
+	DiffKindExtra DiffKind = "extra"
+	// DiffKindChanged is used when a value is present in both but is not equal.
+	DiffKindChanged DiffKind = "changed"
+)
+
+const (
+	ansiReset  = "\033[0m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+
+	// maxDiffValueLength is the maximum length of a value rendered in a diff before it is truncated.
+	maxDiffValueLength = 100
+)
+
+// JSONDifference describes a single difference between two JSON values.
+type JSONDifference struct {
+	// Path is the gjson path to the value.
+	Path string
+	// Expected is the expected value. It is nil when Kind is DiffKindExtra.
+	Expected interface{}
+	// Actual is the actual value. It is nil when Kind is DiffKindMissing.
+	Actual interface{}
+	// Kind is the kind of difference.
+	Kind DiffKind
+}
+
+// JSONDiff is a list of differences between two JSON values.
+type JSONDiff []JSONDifference
+
+// DiffJSON returns the differences between the expected and actual JSON values.
+// The values are expected to be in the form produced by json.Unmarshal into an interface{}.
+func DiffJSON(expected interface{}, actual interface{}) JSONDiff {
+	return diffJSON("", expected, actual, make(JSONDiff, 0))
+}
+
+func diffJSON(path string, expected interface{}, actual interface{}, diff JSONDiff) JSONDiff {
+	switch exp := expected.(type) {
+	case map[string]interface{}:
+		act, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(exp)+len(act))
+		for k := range exp {
+			keys = append(keys, k)
+		}
+		for k := range act {
+			if _, ok := exp[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			expVal, expOk := exp[k]
+			actVal, actOk := act[k]
+			childPath := jsonPathJoin(path, k)
+			switch {
+			case !actOk:
+				diff = append(diff, JSONDifference{Path: childPath, Expected: expVal, Kind: DiffKindMissing})
+			case !expOk:
+				diff = append(diff, JSONDifference{Path: childPath, Actual: actVal, Kind: DiffKindExtra})
+			default:
+				diff = diffJSON(childPath, expVal, actVal, diff)
+			}
+		}
+		return diff
+
+	case []interface{}:
+		act, ok := actual.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(exp) || i < len(act); i++ {
+			childPath := jsonPathJoin(path, strconv.Itoa(i))
+			switch {
+			case i >= len(act):
+				diff = append(diff, JSONDifference{Path: childPath, Expected: exp[i], Kind: DiffKindMissing})
+			case i >= len(exp):
+				diff = append(diff, JSONDifference{Path: childPath, Actual: act[i], Kind: DiffKindExtra})
+			default:
+				diff = diffJSON(childPath, exp[i], act[i], diff)
+			}
+		}
+		return diff
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		diff = append(diff, JSONDifference{Path: path, Expected: expected, Actual: actual, Kind: DiffKindChanged})
+	}
+	return diff
+}
+
+// jsonPathJoin appends the given key to a gjson path, escaping any special characters.
+func jsonPathJoin(path string, key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		switch r {
+		case '.', '*', '?', '|', '#', '@', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	if path == "" {
+		return b.String()
+	}
+	return path + "." + b.String()
+}
+
+// WithPathPrefix returns a copy of the diff with each path prefixed by the given path.
+func (d JSONDiff) WithPathPrefix(prefix string) JSONDiff {
+	if prefix == "" {
+		return d
+	}
+	res := make(JSONDiff, len(d))
+	for i, diff := range d {
+		res[i] = diff
+		if diff.Path == "" {
+			res[i].Path = prefix
+		} else {
+			res[i].Path = prefix + "." + diff.Path
+		}
+	}
+	return res
+}
+
+// String returns the diff rendered without colours.
+func (d JSONDiff) String() string {
+	return d.Render(false)
+}
+
+// Render returns a compact, line based representation of the diff.
+// If colour is true, ANSI colour codes are used to highlight each line.
+func (d JSONDiff) Render(colour bool) string {
+	lines := make([]string, len(d))
+	for i, diff := range d {
+		lines[i] = diff.Render(colour)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Render returns a single line representation of the difference.
+// If colour is true, ANSI colour codes are used to highlight the line.
+func (d JSONDifference) Render(colour bool) string {
+	path := d.Path
+	if path == "" {
+		path = "(root)"
+	}
+
+	var symbol, ansi, line string
+	switch d.Kind {
+	case DiffKindMissing:
+		symbol, ansi = "-", ansiRed
+		line = fmt.Sprintf("%s: missing, expected %s", path, fmtDiffValue(d.Expected))
+	case DiffKindExtra:
+		symbol, ansi = "+", ansiGreen
+		line = fmt.Sprintf("%s: unexpected %s", path, fmtDiffValue(d.Actual))
+	default:
+		symbol, ansi = "~", ansiYellow
+		line = fmt.Sprintf("%s: expected %s, got %s", path, fmtDiffValue(d.Expected), fmtDiffValue(d.Actual))
+	}
+
+	if colour {
+		return fmt.Sprintf("%s%s %s%s", ansi, symbol, line, ansiReset)
+	}
+	return fmt.Sprintf("%s %s", symbol, line)
+}
+
+// fmtDiffValue returns the value as compact JSON, truncated if it is too long.
+func fmtDiffValue(val interface{}) string {
+	var str string
+	if b, err := json.Marshal(val); err == nil {
+		str = string(b)
+	} else {
+		str = fmt.Sprintf("%v", val)
+	}
+	if utf8.RuneCountInString(str) > maxDiffValueLength {
+		str = string([]rune(str)[:maxDiffValueLength]) + "..."
+	}
+	return str
+}
+
+// ColourError is implemented by errors that can render themselves using ANSI colour codes.
+type ColourError interface {
+	error
+	// ColourError returns the same message as Error, with colour codes added.
+	ColourError() string
+}
@@ -0,0 +1,100 @@
+package check_test
+
+import (
+	"encoding/json"
+	"github.com/tomwright/apitestr/check"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := [...]struct {
+		desc     string
+		expected string
+		actual   string
+		diff     check.JSONDiff
+		rendered string
+	}{
+		{
+			desc:     "equal",
+			expected: `{"a":[1,2],"b":{"c":null}}`,
+			actual:   `{"b":{"c":null},"a":[1,2]}`,
+			diff:     check.JSONDiff{},
+			rendered: ``,
+		},
+		{
+			desc:     "changed, missing and extra keys",
+			expected: `{"a":1,"b":2,"c.d":3}`,
+			actual:   `{"a":5,"c.d":3,"e":true}`,
+			diff: check.JSONDiff{
+				{Path: "a", Expected: float64(1), Actual: float64(5), Kind: check.DiffKindChanged},
+				{Path: "b", Expected: float64(2), Kind: check.DiffKindMissing},
+				{Path: "e", Actual: true, Kind: check.DiffKindExtra},
+			},
+			rendered: "~ a: expected 1, got 5\n- b: missing, expected 2\n+ e: unexpected true",
+		},
+		{
+			desc:     "nested arrays",
+			expected: `[{"id":1},{"id":2}]`,
+			actual:   `[{"id":1},{"id":3},{"id":4}]`,
+			diff: check.JSONDiff{
+				{Path: "1.id", Expected: float64(2), Actual: float64(3), Kind: check.DiffKindChanged},
+				{Path: "2", Actual: map[string]interface{}{"id": float64(4)}, Kind: check.DiffKindExtra},
+			},
+			rendered: "~ 1.id: expected 2, got 3\n+ 2: unexpected {\"id\":4}",
+		},
+		{
+			desc:     "root type change",
+			expected: `{"id":1}`,
+			actual:   `[]`,
+			diff: check.JSONDiff{
+				{Path: "", Expected: map[string]interface{}{"id": float64(1)}, Actual: []interface{}{}, Kind: check.DiffKindChanged},
+			},
+			rendered: "~ (root): expected {\"id\":1}, got []",
+		},
+		{
+			desc:     "special characters in keys are escaped",
+			expected: `{"a.b":{"c":1}}`,
+			actual:   `{"a.b":{"c":2}}`,
+			diff: check.JSONDiff{
+				{Path: `a\.b.c`, Expected: float64(1), Actual: float64(2), Kind: check.DiffKindChanged},
+			},
+			rendered: "~ a\\.b.c: expected 1, got 2",
+		},
+		{
+			desc:     "long values are truncated on a rune boundary",
+			expected: `{"a":"` + strings.Repeat("a", 98) + `ééé"}`,
+			actual:   `{"a":1}`,
+			diff: check.JSONDiff{
+				{Path: "a", Expected: strings.Repeat("a", 98) + "ééé", Actual: float64(1), Kind: check.DiffKindChanged},
+			},
+			rendered: "~ a: expected \"" + strings.Repeat("a", 98) + "é..., got 1",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var exp, act interface{}
+			if err := json.Unmarshal([]byte(tc.expected), &exp); err != nil {
+				t.Fatalf("could not unmarshal expected: %s", err)
+			}
+			if err := json.Unmarshal([]byte(tc.actual), &act); err != nil {
+				t.Fatalf("could not unmarshal actual: %s", err)
+			}
+
+			diff := check.DiffJSON(exp, act)
+			if !reflect.DeepEqual(tc.diff, diff) {
+				t.Errorf("expected diff:\n%#v\ngot:\n%#v", tc.diff, diff)
+			}
+			if exp, got := tc.rendered, diff.String(); exp != got {
+				t.Errorf("expected rendered diff:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
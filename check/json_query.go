@@ -0,0 +1,55 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/tidwall/gjson"
+)
+
+// queryJSONArray queries the JSON body using `query` and returns the elements of the resulting array.
+func queryJSONArray(body []byte, query string) ([]gjson.Result, error) {
+	r := gjson.ParseBytes(body).Get(query)
+
+	if !r.Exists() {
+		return nil, &JSONQueryValueMissingError{
+			Query: query,
+		}
+	}
+
+	if !r.IsArray() {
+		return nil, &UnexpectedJSONTypeError{
+			Query:    query,
+			Expected: JSONTypeArray,
+			Actual:   jsonType(r.Value()),
+		}
+	}
+
+	return r.Array(), nil
+}
+
+// jsonElementValue returns the value found at `path` within the given element.
+// If `path` is empty the element itself is used.
+func jsonElementValue(element gjson.Result, path string) gjson.Result {
+	if path == "" {
+		return element
+	}
+	return element.Get(path)
+}
+
+// canonicalJSON returns the given value encoded as JSON, such that equal values always give the same result.
+func canonicalJSON(val interface{}) string {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return string(b)
+}
+
+// jsonElementPath returns the full path to `path` within the element at index `i` of the array found by `query`.
+func jsonElementPath(query string, i int, path string) string {
+	elementPath := fmt.Sprintf("%s.%d", query, i)
+	if path == "" {
+		return elementPath
+	}
+	return elementPath + "." + path
+}
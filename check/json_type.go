@@ -0,0 +1,67 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// JSON value type names, as returned by jsonType.
+// JSONTypeInteger is never returned by jsonType, but may be used to check that a number is a whole number.
+const (
+	JSONTypeObject  = "object"
+	JSONTypeArray   = "array"
+	JSONTypeString  = "string"
+	JSONTypeNumber  = "number"
+	JSONTypeInteger = "integer"
+	JSONTypeBoolean = "boolean"
+	JSONTypeNull    = "null"
+)
+
+// UnexpectedJSONTypeError is returned when a JSON value is not of the expected type.
+type UnexpectedJSONTypeError struct {
+	// Query is the JSON query, if any.
+	Query string
+	// Expected is the expected JSON type.
+	Expected string
+	// Actual is the actual JSON type.
+	Actual string
+}
+
+// Error returns an error string.
+func (e *UnexpectedJSONTypeError) Error() string {
+	if e.Query == "" {
+		return fmt.Sprintf("unexpected json type: expected %v, got %v", e.Expected, e.Actual)
+	}
+	return fmt.Sprintf("unexpected json type at %v: expected %v, got %v", e.Query, e.Expected, e.Actual)
+}
+
+// jsonType returns the name of the JSON type that the given value would be encoded as.
+func jsonType(val interface{}) string {
+	if val == nil {
+		return JSONTypeNull
+	}
+	switch reflect.ValueOf(val).Kind() {
+	case reflect.Map, reflect.Struct:
+		return JSONTypeObject
+	case reflect.Slice, reflect.Array:
+		if _, ok := val.([]byte); ok {
+			return JSONTypeString
+		}
+		return JSONTypeArray
+	case reflect.String:
+		return JSONTypeString
+	case reflect.Bool:
+		return JSONTypeBoolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return JSONTypeNumber
+	case reflect.Ptr, reflect.Interface:
+		rv := reflect.ValueOf(val)
+		if rv.IsNil() {
+			return JSONTypeNull
+		}
+		return jsonType(rv.Elem().Interface())
+	}
+	return fmt.Sprintf("%T", val)
+}
@@ -0,0 +1,306 @@
+package check
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/tidwall/gjson"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JWTMissingError is returned when a token cannot be found in the response.
+type JWTMissingError struct {
+	// Source describes where the token was expected.
+	Source string
+}
+
+// Error returns an error string.
+func (e *JWTMissingError) Error() string {
+	return fmt.Sprintf("jwt at %v is missing", e.Source)
+}
+
+// InvalidJWTError is returned when a token cannot be decoded or verified.
+type InvalidJWTError struct {
+	// Source describes where the token was found.
+	Source string
+	// Reason describes why the token is invalid.
+	Reason string
+}
+
+// Error returns an error string.
+func (e *InvalidJWTError) Error() string {
+	return fmt.Sprintf("invalid jwt at %v: %v", e.Source, e.Reason)
+}
+
+// UnexpectedJWTClaimError is returned when a check fails.
+type UnexpectedJWTClaimError struct {
+	// Source describes where the token was found.
+	Source string
+	// Claim is the name of the claim.
+	Claim string
+	// Expected is a description of the expected value.
+	Expected string
+	// Actual is the actual value.
+	Actual interface{}
+}
+
+// Error returns an error string.
+func (e *UnexpectedJWTClaimError) Error() string {
+	actual := "missing"
+	if e.Actual != nil {
+		actual = canonicalJSON(e.Actual)
+	}
+	return fmt.Sprintf("unexpected jwt claim %v at %v: expected %v, got %v", e.Claim, e.Source, e.Expected, actual)
+}
+
+// JWTChecker decodes the JWT found at the JSON query `Query`, or in the response header `Header`, and validates it.
+// A `Bearer ` prefix is removed from header values.
+// If `Secret` or `Keys` are set the signature is verified. HMAC algorithms are only verified using `Secret` or HMAC keys, and RSA and ECDSA algorithms only using public keys.
+// `Issuer`, `Audience` and `Claims` are only checked if set. If `ResolveData` is true they, and `Secret`, may be data references.
+// If `NotExpired` is true the `exp` claim must be in the future, and the `nbf` claim must not be, allowing for `Leeway`.
+// `ClaimDataIDs` maps claim names to the data ID they are stored under. The raw token is stored under `DataID`.
+type JWTChecker struct {
+	Query        string
+	Header       string
+	Secret       string
+	Keys         []JWTKey
+	Issuer       string
+	Audience     string
+	NotExpired   bool
+	Leeway       time.Duration
+	Claims       map[string]interface{}
+	ClaimDataIDs map[string]string
+	ResolveData  bool
+	DataID       string
+}
+
+// Check performs the JWT check
+func (c *JWTChecker) Check(ctx context.Context, response *http.Response) error {
+	token, err := c.token(response)
+	if err != nil {
+		return err
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return c.invalid("expected 3 parts, got %d", len(parts))
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return c.invalid("could not decode header: %s", err)
+	}
+	var claims map[string]interface{}
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return c.invalid("could not decode claims: %s", err)
+	}
+
+	if c.Secret != "" || len(c.Keys) > 0 {
+		if err := c.verify(ctx, header.Alg, header.Kid, parts); err != nil {
+			return err
+		}
+	}
+
+	if err := c.checkClaims(ctx, claims); err != nil {
+		return err
+	}
+
+	if err := ContextWithOptionalDataID(ctx, c.DataID, token); err != nil {
+		return err
+	}
+	for claim, dataID := range c.ClaimDataIDs {
+		val, ok := claims[claim]
+		if !ok {
+			return &UnexpectedJWTClaimError{
+				Source:   c.source(),
+				Claim:    claim,
+				Expected: "a value to capture",
+			}
+		}
+		if err := ContextWithOptionalDataID(ctx, dataID, val); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *JWTChecker) source() string {
+	if c.Header != "" {
+		return "header " + c.Header
+	}
+	return c.Query
+}
+
+func (c *JWTChecker) invalid(format string, args ...interface{}) error {
+	return &InvalidJWTError{
+		Source: c.source(),
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// token returns the raw token from the response.
+func (c *JWTChecker) token(response *http.Response) (string, error) {
+	var token string
+	if c.Header != "" {
+		token = strings.TrimSpace(response.Header.Get(c.Header))
+		if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+			token = strings.TrimSpace(token[7:])
+		}
+	} else {
+		body, err := readResponseBody(response)
+		if err != nil {
+			return "", err
+		}
+		r := gjson.GetBytes(body, c.Query)
+		if r.Type != gjson.String && r.Exists() {
+			return "", c.invalid("expected a string, got %s", jsonType(r.Value()))
+		}
+		token = r.String()
+	}
+	if token == "" {
+		return "", &JWTMissingError{
+			Source: c.source(),
+		}
+	}
+	return token, nil
+}
+
+// verify verifies the token signature using the secret or keys.
+func (c *JWTChecker) verify(ctx context.Context, alg string, kid string, parts []string) error {
+	signature, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[2], "="))
+	if err != nil {
+		return c.invalid("could not decode signature: %s", err)
+	}
+	signingInput := parts[0] + "." + parts[1]
+
+	hmacAlg := strings.HasPrefix(alg, "HS")
+
+	candidates := make([]JWTKey, 0, len(c.Keys)+1)
+	if hmacAlg && c.Secret != "" {
+		secret, err := resolveOptionalDataReferenceString(ctx, c.ResolveData, c.Secret)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, JWTKey{Key: []byte(secret)})
+	}
+	for _, key := range c.Keys {
+		if kid != "" && key.ID != "" && key.ID != kid {
+			continue
+		}
+		// only use HMAC secrets with HMAC algorithms to prevent public keys being used as secrets
+		if _, isSecret := key.Key.([]byte); isSecret == hmacAlg {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) == 0 {
+		return c.invalid("no key found to verify algorithm %s with kid `%s`", alg, kid)
+	}
+
+	for _, key := range candidates {
+		if err = verifyJWTSignature(alg, key.Key, signingInput, signature); err == nil {
+			return nil
+		}
+	}
+	return c.invalid("%s", err)
+}
+
+// checkClaims validates the claims against the expected values.
+func (c *JWTChecker) checkClaims(ctx context.Context, claims map[string]interface{}) error {
+	claimErr := func(claim string, expected string) error {
+		return &UnexpectedJWTClaimError{
+			Source:   c.source(),
+			Claim:    claim,
+			Expected: expected,
+			Actual:   claims[claim],
+		}
+	}
+
+	if c.Issuer != "" {
+		issuer, err := resolveOptionalDataReferenceString(ctx, c.ResolveData, c.Issuer)
+		if err != nil {
+			return err
+		}
+		if iss, _ := claims["iss"].(string); iss != issuer {
+			return claimErr("iss", canonicalJSON(issuer))
+		}
+	}
+
+	if c.Audience != "" {
+		audience, err := resolveOptionalDataReferenceString(ctx, c.ResolveData, c.Audience)
+		if err != nil {
+			return err
+		}
+		if !jwtAudienceContains(claims["aud"], audience) {
+			return claimErr("aud", fmt.Sprintf("audience %s", canonicalJSON(audience)))
+		}
+	}
+
+	if c.NotExpired {
+		t := now()
+		exp, ok := claims["exp"].(float64)
+		if !ok || !jwtTime(exp).Add(c.Leeway).After(t) {
+			return claimErr("exp", fmt.Sprintf("a time after now (%d)", t.Unix()))
+		}
+		if nbf, ok := claims["nbf"]; ok {
+			nbfNum, ok := nbf.(float64)
+			if !ok || jwtTime(nbfNum).Add(-c.Leeway).After(t) {
+				return claimErr("nbf", fmt.Sprintf("a time before now (%d)", t.Unix()))
+			}
+		}
+	}
+
+	names := make([]string, 0, len(c.Claims))
+	for name := range c.Claims {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		expected, err := resolveOptionalDataReferences(ctx, c.ResolveData, c.Claims[name])
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(expected, claims[name]) {
+			return claimErr(name, canonicalJSON(expected))
+		}
+	}
+
+	return nil
+}
+
+// decodeJWTPart decodes the given base64url encoded JSON into v.
+func decodeJWTPart(part string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// jwtAudienceContains returns true if the `aud` claim is, or contains, the given audience.
+func jwtAudienceContains(aud interface{}, audience string) bool {
+	switch a := aud.(type) {
+	case string:
+		return a == audience
+	case []interface{}:
+		for _, item := range a {
+			if item == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jwtTime converts a JWT NumericDate to a time.
+func jwtTime(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
@@ -0,0 +1,249 @@
+package check
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+)
+
+// JWTKey is a public key used to verify JWT signatures.
+type JWTKey struct {
+	// ID is the key ID, matched against the `kid` header of a token. It may be empty.
+	ID string
+	// Key is an *rsa.PublicKey, *ecdsa.PublicKey or []byte HMAC secret.
+	Key interface{}
+}
+
+// ReadJWTKeyFile reads the keys in the given PEM or JWKS file.
+func ReadJWTKeyFile(path string) ([]JWTKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read key file: %w", err)
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return ParseJWKS(data)
+	}
+	return ParsePEMKeys(data)
+}
+
+// ParsePEMKeys parses every public key and certificate in the given PEM data.
+func ParsePEMKeys(data []byte) ([]JWTKey, error) {
+	keys := make([]JWTKey, 0)
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		var key interface{}
+		var err error
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		case "CERTIFICATE":
+			var cert *x509.Certificate
+			cert, err = x509.ParseCertificate(block.Bytes)
+			if err == nil {
+				key = cert.PublicKey
+			}
+		default:
+			return nil, fmt.Errorf("unhandled PEM block type `%s`", block.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not parse PEM %s: %w", strings.ToLower(block.Type), err)
+		}
+		keys = append(keys, JWTKey{Key: key})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no PEM keys found")
+	}
+	return keys, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// ParseJWKS parses the keys in the given JSON Web Key Set.
+// RSA, EC and oct (HMAC) keys are supported.
+func ParseJWKS(data []byte) ([]JWTKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("could not parse JWKS: %w", err)
+	}
+	keys := make([]JWTKey, len(set.Keys))
+	for i, k := range set.Keys {
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse JWKS key [%d]: %w", i, err)
+		}
+		keys[i] = JWTKey{ID: k.Kid, Key: key}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no JWKS keys found")
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64URLInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid `n`: %w", err)
+		}
+		e, err := base64URLInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid `e`: %w", err)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unhandled curve `%s`", k.Crv)
+		}
+		x, err := base64URLInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid `x`: %w", err)
+		}
+		y, err := base64URLInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid `y`: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.K, "="))
+		if err != nil {
+			return nil, fmt.Errorf("invalid `k`: %w", err)
+		}
+		return secret, nil
+	}
+	return nil, fmt.Errorf("unhandled key type `%s`", k.Kty)
+}
+
+func base64URLInt(str string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(str, "="))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// jwtHash returns the hash used by the given JWT algorithm, e.g. SHA-256 for RS256.
+func jwtHash(alg string) (crypto.Hash, bool) {
+	if len(alg) != 5 {
+		return 0, false
+	}
+	switch alg[2:] {
+	case "256":
+		return crypto.SHA256, true
+	case "384":
+		return crypto.SHA384, true
+	case "512":
+		return crypto.SHA512, true
+	}
+	return 0, false
+}
+
+// verifyJWTSignature verifies the signature of the signing input using the given algorithm and key.
+func verifyJWTSignature(alg string, key interface{}, signingInput string, signature []byte) error {
+	hash, ok := jwtHash(alg)
+	if !ok {
+		return fmt.Errorf("unhandled algorithm `%s`", alg)
+	}
+
+	var hasher = sha256.New
+	switch hash {
+	case crypto.SHA384:
+		hasher = sha512.New384
+	case crypto.SHA512:
+		hasher = sha512.New
+	}
+
+	switch alg[:2] {
+	case "HS":
+		secret, ok := key.([]byte)
+		if !ok {
+			return fmt.Errorf("algorithm `%s` requires an HMAC secret", alg)
+		}
+		mac := hmac.New(hasher, secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return fmt.Errorf("signature does not match")
+		}
+		return nil
+	}
+
+	h := hasher()
+	h.Write([]byte(signingInput))
+	digest := h.Sum(nil)
+
+	switch alg[:2] {
+	case "RS", "PS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("algorithm `%s` requires an RSA key", alg)
+		}
+		var err error
+		if alg[:2] == "RS" {
+			err = rsa.VerifyPKCS1v15(pub, hash, digest, signature)
+		} else {
+			err = rsa.VerifyPSS(pub, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto, Hash: hash})
+		}
+		if err != nil {
+			return fmt.Errorf("signature does not match")
+		}
+		return nil
+
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("algorithm `%s` requires an ECDSA key", alg)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("signature does not match")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return fmt.Errorf("signature does not match")
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unhandled algorithm `%s`", alg)
+}
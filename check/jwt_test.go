@@ -0,0 +1,270 @@
+package check_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"github.com/tomwright/apitestr/check"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func encodeJWT(t *testing.T, alg string, kid string, claims map[string]interface{}, sign func(signingInput []byte) []byte) string {
+	header := map[string]interface{}{"alg": alg, "typ": "JWT"}
+	if kid != "" {
+		header["kid"] = kid
+	}
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		t.Fatalf("could not marshal header: %s", err)
+	}
+	claimsBytes, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("could not marshal claims: %s", err)
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(headerBytes) + "." + base64.RawURLEncoding.EncodeToString(claimsBytes)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signingInput)))
+}
+
+func sha256Digest(b []byte) []byte {
+	h := sha256.Sum256(b)
+	return h[:]
+}
+
+// TestJWTChecker_Check is not parallel because it overrides the current time.
+func TestJWTChecker_Check(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	claims := map[string]interface{}{
+		"iss":  "https://auth.example.com",
+		"aud":  []string{"api", "web"},
+		"sub":  "user-123",
+		"role": "admin",
+		"exp":  now.Add(time.Hour).Unix(),
+		"nbf":  now.Add(-time.Minute).Unix(),
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("could not generate rsa key: %s", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate ecdsa key: %s", err)
+	}
+
+	hs256 := encodeJWT(t, "HS256", "", claims, func(in []byte) []byte {
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(in)
+		return mac.Sum(nil)
+	})
+	rs256 := encodeJWT(t, "RS256", "rsa", claims, func(in []byte) []byte {
+		sig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, sha256Digest(in))
+		if err != nil {
+			t.Fatalf("could not sign: %s", err)
+		}
+		return sig
+	})
+	ps256 := encodeJWT(t, "PS256", "rsa", claims, func(in []byte) []byte {
+		sig, err := rsa.SignPSS(rand.Reader, rsaKey, crypto.SHA256, sha256Digest(in), nil)
+		if err != nil {
+			t.Fatalf("could not sign: %s", err)
+		}
+		return sig
+	})
+	es256 := encodeJWT(t, "ES256", "ec", claims, func(in []byte) []byte {
+		r, s, err := ecdsa.Sign(rand.Reader, ecKey, sha256Digest(in))
+		if err != nil {
+			t.Fatalf("could not sign: %s", err)
+		}
+		sig := make([]byte, 64)
+		rBytes, sBytes := r.Bytes(), s.Bytes()
+		copy(sig[32-len(rBytes):32], rBytes)
+		copy(sig[64-len(sBytes):], sBytes)
+		return sig
+	})
+
+	pubBytes, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatalf("could not marshal public key: %s", err)
+	}
+	pemKeys, err := check.ParsePEMKeys(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes}))
+	if err != nil {
+		t.Fatalf("could not parse pem keys: %s", err)
+	}
+
+	b64Int := func(b []byte) string {
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	jwksKeys, err := check.ParseJWKS([]byte(fmt.Sprintf(`{"keys": [
+		{"kty": "RSA", "kid": "rsa", "n": %q, "e": "AQAB"},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": %q, "y": %q}
+	]}`, b64Int(rsaKey.N.Bytes()), b64Int(ecKey.X.Bytes()), b64Int(ecKey.Y.Bytes()))))
+	if err != nil {
+		t.Fatalf("could not parse jwks: %s", err)
+	}
+
+	body := fmt.Sprintf(`{"hs256": %q, "rs256": %q, "ps256": %q, "es256": %q, "bad": "abc"}`, hs256, rs256, ps256, es256)
+
+	tests := [...]struct {
+		desc string
+		// now is the current time used by the check. It defaults to the time the tokens were issued.
+		now          time.Time
+		checker      *check.JWTChecker
+		expectedErr  string
+		expectedData map[string]interface{}
+	}{
+		{
+			desc:    "decode only",
+			checker: &check.JWTChecker{Query: "hs256"},
+		},
+		{
+			desc:    "header",
+			checker: &check.JWTChecker{Header: "Authorization", Secret: "secret"},
+		},
+		{
+			desc:    "hmac",
+			checker: &check.JWTChecker{Query: "hs256", Secret: "secret"},
+		},
+		{
+			desc:    "hmac data reference",
+			checker: &check.JWTChecker{Query: "hs256", Secret: "$.secret", ResolveData: true},
+		},
+		{
+			desc:        "hmac wrong secret",
+			checker:     &check.JWTChecker{Query: "hs256", Secret: "nope"},
+			expectedErr: "invalid jwt at hs256: signature does not match",
+		},
+		{
+			desc:        "hmac with public key",
+			checker:     &check.JWTChecker{Query: "hs256", Keys: pemKeys},
+			expectedErr: "invalid jwt at hs256: no key found to verify algorithm HS256 with kid ``",
+		},
+		{
+			desc:    "rsa pem",
+			checker: &check.JWTChecker{Query: "rs256", Keys: pemKeys},
+		},
+		{
+			desc:    "rsa pss jwks",
+			checker: &check.JWTChecker{Query: "ps256", Keys: jwksKeys},
+		},
+		{
+			desc:    "ecdsa jwks",
+			checker: &check.JWTChecker{Query: "es256", Keys: jwksKeys},
+		},
+		{
+			desc:        "ecdsa wrong key",
+			checker:     &check.JWTChecker{Query: "es256", Keys: pemKeys},
+			expectedErr: "invalid jwt at es256: algorithm `ES256` requires an ECDSA key",
+		},
+		{
+			desc:        "rsa with secret",
+			checker:     &check.JWTChecker{Query: "rs256", Secret: "secret"},
+			expectedErr: "invalid jwt at rs256: no key found to verify algorithm RS256 with kid `rsa`",
+		},
+		{
+			desc:    "claims",
+			checker: &check.JWTChecker{Query: "es256", Issuer: "https://auth.example.com", Audience: "web", NotExpired: true, Claims: map[string]interface{}{"sub": "$.userId", "role": "admin"}, ResolveData: true},
+		},
+		{
+			desc:    "issuer and audience data references",
+			checker: &check.JWTChecker{Query: "es256", Issuer: "$.issuer", Audience: "$.audience", ResolveData: true},
+		},
+		{
+			desc:        "wrong audience data reference",
+			checker:     &check.JWTChecker{Query: "es256", Audience: "$.userId", ResolveData: true},
+			expectedErr: "unexpected jwt claim aud at es256: expected audience \"user-123\", got [\"api\",\"web\"]",
+		},
+		{
+			desc:        "wrong issuer",
+			checker:     &check.JWTChecker{Query: "es256", Issuer: "https://other.example.com"},
+			expectedErr: "unexpected jwt claim iss at es256: expected \"https://other.example.com\", got \"https://auth.example.com\"",
+		},
+		{
+			desc:        "wrong audience",
+			checker:     &check.JWTChecker{Query: "es256", Audience: "admin"},
+			expectedErr: "unexpected jwt claim aud at es256: expected audience \"admin\", got [\"api\",\"web\"]",
+		},
+		{
+			desc:    "expired within leeway",
+			now:     now.Add(time.Hour + time.Second),
+			checker: &check.JWTChecker{Query: "es256", NotExpired: true, Leeway: time.Minute},
+		},
+		{
+			desc:        "expired",
+			now:         now.Add(2 * time.Hour),
+			checker:     &check.JWTChecker{Query: "es256", NotExpired: true},
+			expectedErr: fmt.Sprintf("unexpected jwt claim exp at es256: expected a time after now (%d), got %d", now.Add(2*time.Hour).Unix(), now.Add(time.Hour).Unix()),
+		},
+		{
+			desc:        "not yet valid",
+			now:         now.Add(-time.Hour),
+			checker:     &check.JWTChecker{Query: "es256", NotExpired: true},
+			expectedErr: fmt.Sprintf("unexpected jwt claim nbf at es256: expected a time before now (%d), got %d", now.Add(-time.Hour).Unix(), now.Add(-time.Minute).Unix()),
+		},
+		{
+			desc:        "wrong claim",
+			checker:     &check.JWTChecker{Query: "es256", Claims: map[string]interface{}{"role": "user"}},
+			expectedErr: "unexpected jwt claim role at es256: expected \"user\", got \"admin\"",
+		},
+		{
+			desc:         "capture",
+			checker:      &check.JWTChecker{Query: "es256", ClaimDataIDs: map[string]string{"sub": "capturedSub"}, DataID: "token"},
+			expectedData: map[string]interface{}{"capturedSub": "user-123", "token": es256},
+		},
+		{
+			desc:        "missing",
+			checker:     &check.JWTChecker{Query: "nope"},
+			expectedErr: "jwt at nope is missing",
+		},
+		{
+			desc:        "malformed",
+			checker:     &check.JWTChecker{Query: "bad"},
+			expectedErr: "invalid jwt at bad: expected 3 parts, got 1",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			checkNow := tc.now
+			if checkNow.IsZero() {
+				checkNow = now
+			}
+			defer check.SetNow(checkNow)()
+
+			data := map[string]interface{}{"secret": "secret", "userId": "user-123", "issuer": "https://auth.example.com", "audience": "web"}
+			ctx := check.ContextWithData(context.Background(), data)
+
+			response := responseWithBody(body)
+			response.Header = map[string][]string{"Authorization": {"Bearer " + hs256}}
+
+			err := tc.checker.Check(ctx, response)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			} else if err == nil {
+				t.Errorf("expected error but got none")
+			} else if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+
+			for k, exp := range tc.expectedData {
+				if got := data[k]; !reflect.DeepEqual(exp, got) {
+					t.Errorf("expected data %s to be %v, got %v", k, exp, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package check
+
+import (
+	"time"
+)
+
+// now returns the current time. Tests override it to check against a fixed time.
+var now = time.Now
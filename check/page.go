@@ -0,0 +1,24 @@
+package check
+
+import (
+	"context"
+)
+
+const (
+	pageCtxKey ctxKey = "page"
+)
+
+// ContextWithPage embeds the number of the page being checked in the context, where the first page is 1.
+func ContextWithPage(ctx context.Context, page int) context.Context {
+	return context.WithValue(ctx, pageCtxKey, page)
+}
+
+// PageFromContext returns the number of the page being checked from the context.
+// 1 is returned if the test is not paginated.
+func PageFromContext(ctx context.Context) int {
+	page, ok := ctx.Value(pageCtxKey).(int)
+	if !ok || page < 1 {
+		return 1
+	}
+	return page
+}
@@ -0,0 +1,132 @@
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+)
+
+// ProblemDetailsContentType is the content type of RFC 7807 problem details.
+const ProblemDetailsContentType = "application/problem+json"
+
+// InvalidProblemDetailsError is returned when a response is not valid problem details.
+type InvalidProblemDetailsError struct {
+	// Violations describes each way in which the response is invalid.
+	Violations Violations
+}
+
+// Error returns an error string.
+func (e *InvalidProblemDetailsError) Error() string {
+	return fmt.Sprintf("invalid problem details: %s", e.Violations)
+}
+
+// ProblemDetailsChecker ensures the http response is a valid RFC 7807 problem details document.
+// The content type must be application/problem+json, the `type`, `title` and `status` members must be present and `status` must equal the response status code.
+// If `Type` is not empty, the `type` member must equal it. Each of `Extensions` must be present with an equal value.
+// If `ResolveData` is true, `Type` and `Extensions` values may be data references. Every violation is reported.
+type ProblemDetailsChecker struct {
+	Type        string
+	Extensions  map[string]interface{}
+	ResolveData bool
+}
+
+// Check performs the ProblemDetails check
+func (c *ProblemDetailsChecker) Check(ctx context.Context, response *http.Response) error {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return err
+	}
+
+	var violations Violations
+
+	contentType := response.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != ProblemDetailsContentType {
+		violations.Add("expected content type %s, got %q", ProblemDetailsContentType, contentType)
+	}
+
+	var problem map[string]interface{}
+	if err := json.Unmarshal(body, &problem); err != nil {
+		violations.Add("body is not a JSON object: %s", err)
+		return &InvalidProblemDetailsError{Violations: violations}
+	}
+
+	problemType, hasType := problem["type"]
+	typeStr, isString := problemType.(string)
+	switch {
+	case !hasType:
+		violations.Add("missing required member `type`")
+	case !isString:
+		violations.Add("member `type` must be a string, got %s", jsonType(problemType))
+	default:
+		if _, err := url.Parse(typeStr); err != nil {
+			violations.Add("member `type` must be a URI reference, got %q", typeStr)
+		}
+	}
+
+	if c.Type != "" && hasType {
+		expected, err := resolveOptionalDataReferenceString(ctx, c.ResolveData, c.Type)
+		if err != nil {
+			return err
+		}
+		if typeStr != expected {
+			violations.Add("expected `type` %q, got %s", expected, canonicalJSON(problemType))
+		}
+	}
+
+	if title, ok := problem["title"]; !ok {
+		violations.Add("missing required member `title`")
+	} else if _, ok := title.(string); !ok {
+		violations.Add("member `title` must be a string, got %s", jsonType(title))
+	}
+
+	status, hasStatus := problem["status"]
+	statusNum, isNumber := status.(float64)
+	switch {
+	case !hasStatus:
+		violations.Add("missing required member `status`")
+	case !isNumber || statusNum != math.Trunc(statusNum):
+		violations.Add("member `status` must be an integer, got %s", canonicalJSON(status))
+	case int(statusNum) != response.StatusCode:
+		violations.Add("member `status` must match the response status code %d, got %d", response.StatusCode, int(statusNum))
+	}
+
+	for _, member := range []string{"detail", "instance"} {
+		if val, ok := problem[member]; ok {
+			if _, ok := val.(string); !ok {
+				violations.Add("member `%s` must be a string, got %s", member, jsonType(val))
+			}
+		}
+	}
+
+	names := make([]string, 0, len(c.Extensions))
+	for name := range c.Extensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		expected, err := resolveOptionalDataReferences(ctx, c.ResolveData, c.Extensions[name])
+		if err != nil {
+			return err
+		}
+		actual, ok := problem[name]
+		if !ok {
+			violations.Add("missing extension member `%s`", name)
+			continue
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			violations.Add("expected extension member `%s` to be %s, got %s", name, canonicalJSON(expected), canonicalJSON(actual))
+		}
+	}
+
+	if len(violations) > 0 {
+		return &InvalidProblemDetailsError{Violations: violations}
+	}
+
+	return nil
+}
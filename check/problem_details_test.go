@@ -0,0 +1,104 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"testing"
+)
+
+func TestProblemDetailsChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := [...]struct {
+		desc        string
+		contentType string
+		status      int
+		body        string
+		checker     *check.ProblemDetailsChecker
+		expectedErr string
+	}{
+		{
+			desc:        "valid",
+			contentType: "application/problem+json; charset=utf-8",
+			status:      404,
+			body:        `{"type": "https://example.com/probs/not-found", "title": "Not Found", "status": 404, "detail": "user 123 not found", "userId": "123"}`,
+			checker: &check.ProblemDetailsChecker{
+				Type:        "https://example.com/probs/not-found",
+				Extensions:  map[string]interface{}{"userId": "$.userId"},
+				ResolveData: true,
+			},
+		},
+		{
+			desc:        "about blank",
+			contentType: "application/problem+json",
+			status:      500,
+			body:        `{"type": "about:blank", "title": "Internal Server Error", "status": 500}`,
+			checker:     &check.ProblemDetailsChecker{},
+		},
+		{
+			desc:        "every violation",
+			contentType: "application/json",
+			status:      400,
+			body:        `{"type": 1, "status": 422, "detail": ["a"], "errors": []}`,
+			checker: &check.ProblemDetailsChecker{
+				Extensions: map[string]interface{}{"errors": []interface{}{"a"}, "traceId": "abc"},
+			},
+			expectedErr: "invalid problem details: 7 violation(s):\n" +
+				"  - expected content type application/problem+json, got \"application/json\"\n" +
+				"  - member `type` must be a string, got number\n" +
+				"  - missing required member `title`\n" +
+				"  - member `status` must match the response status code 400, got 422\n" +
+				"  - member `detail` must be a string, got array\n" +
+				"  - expected extension member `errors` to be [\"a\"], got []\n" +
+				"  - missing extension member `traceId`",
+		},
+		{
+			desc:        "wrong type",
+			contentType: "application/problem+json",
+			status:      400,
+			body:        `{"type": "https://example.com/probs/other", "title": "Other", "status": "400"}`,
+			checker:     &check.ProblemDetailsChecker{Type: "https://example.com/probs/validation"},
+			expectedErr: "invalid problem details: 2 violation(s):\n" +
+				"  - expected `type` \"https://example.com/probs/validation\", got \"https://example.com/probs/other\"\n" +
+				"  - member `status` must be an integer, got \"400\"",
+		},
+		{
+			desc:        "not json",
+			contentType: "text/plain",
+			status:      500,
+			body:        `oops`,
+			checker:     &check.ProblemDetailsChecker{},
+			expectedErr: "invalid problem details: 2 violation(s):\n" +
+				"  - expected content type application/problem+json, got \"text/plain\"\n" +
+				"  - body is not a JSON object: invalid character 'o' looking for beginning of value",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			response := responseWithBody(tc.body)
+			response.StatusCode = tc.status
+			response.Header = map[string][]string{"Content-Type": {tc.contentType}}
+
+			ctx := check.ContextWithData(context.Background(), map[string]interface{}{"userId": "123"})
+
+			err := tc.checker.Check(ctx, response)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("expected error but got none")
+				return
+			}
+			if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
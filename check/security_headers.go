@@ -0,0 +1,153 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultHSTSMinMaxAge is the minimum HSTS max-age used by SecurityHeadersChecker if no other value is given.
+const DefaultHSTSMinMaxAge = 365 * 24 * time.Hour
+
+// versionPattern matches header values that contain a version number, such as `nginx/1.19.0`.
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)+|/\s*\d`)
+
+// SecurityHeadersError is returned when a response does not meet the security header policy.
+type SecurityHeadersError struct {
+	// Violations describes each violation of the policy.
+	Violations Violations
+}
+
+// Error returns an error string.
+func (e *SecurityHeadersError) Error() string {
+	return fmt.Sprintf("insecure headers: %s", e.Violations)
+}
+
+// SecurityHeadersChecker ensures the http response sets the headers used to harden it. By default it requires:
+//   - Strict-Transport-Security with a max-age of at least `HSTSMinMaxAge`, which defaults to DefaultHSTSMinMaxAge
+//   - X-Content-Type-Options: nosniff
+//   - X-Frame-Options of DENY or SAMEORIGIN, or a Content-Security-Policy with frame-ancestors
+//   - Content-Security-Policy
+//   - Server and X-Powered-By headers that do not contain a version number
+//
+// Each of these can be disabled using the matching `Ignore` field.
+// Each of `Required` must be present and match its pattern, and none of `Forbidden` may be present. Every violation is reported.
+type SecurityHeadersChecker struct {
+	IgnoreHSTS               bool
+	HSTSMinMaxAge            time.Duration
+	HSTSIncludeSubDomains    bool
+	IgnoreContentTypeOptions bool
+	IgnoreFrameProtection    bool
+	IgnoreCSP                bool
+	IgnoreServerVersion      bool
+	Required                 map[string]*regexp.Regexp
+	Forbidden                []string
+}
+
+// Check performs the SecurityHeaders check
+func (c *SecurityHeadersChecker) Check(ctx context.Context, response *http.Response) error {
+	var violations Violations
+
+	csp := response.Header.Get("Content-Security-Policy")
+
+	if !c.IgnoreHSTS {
+		if violation := c.checkHSTS(response.Header.Get("Strict-Transport-Security")); violation != "" {
+			violations.Add("%s", violation)
+		}
+	}
+
+	if !c.IgnoreContentTypeOptions {
+		if got := response.Header.Get("X-Content-Type-Options"); !strings.EqualFold(strings.TrimSpace(got), "nosniff") {
+			violations.Add("X-Content-Type-Options: expected nosniff, got %q", got)
+		}
+	}
+
+	if !c.IgnoreFrameProtection {
+		frameOptions := strings.ToUpper(strings.TrimSpace(response.Header.Get("X-Frame-Options")))
+		if frameOptions != "DENY" && frameOptions != "SAMEORIGIN" && !strings.Contains(strings.ToLower(csp), "frame-ancestors") {
+			violations.Add("X-Frame-Options: expected DENY or SAMEORIGIN, or a Content-Security-Policy with frame-ancestors, got %q", response.Header.Get("X-Frame-Options"))
+		}
+	}
+
+	if !c.IgnoreCSP && strings.TrimSpace(csp) == "" {
+		violations.Add("Content-Security-Policy: expected a policy, got none")
+	}
+
+	if !c.IgnoreServerVersion {
+		for _, name := range []string{"Server", "X-Powered-By"} {
+			if got := response.Header.Get(name); versionPattern.MatchString(got) {
+				violations.Add("%s: expected no version number, got %q", name, got)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(c.Required))
+	for name := range c.Required {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values, ok := response.Header[http.CanonicalHeaderKey(name)]
+		if !ok {
+			violations.Add("%s: expected header to be set", http.CanonicalHeaderKey(name))
+			continue
+		}
+		got := strings.Join(values, ", ")
+		if pattern := c.Required[name]; pattern != nil && !pattern.MatchString(got) {
+			violations.Add("%s: expected match for pattern %s, got %q", http.CanonicalHeaderKey(name), pattern, got)
+		}
+	}
+
+	for _, name := range c.Forbidden {
+		if got, ok := response.Header[http.CanonicalHeaderKey(name)]; ok {
+			violations.Add("%s: expected header not to be set, got %q", http.CanonicalHeaderKey(name), strings.Join(got, ", "))
+		}
+	}
+
+	if len(violations) > 0 {
+		return &SecurityHeadersError{Violations: violations}
+	}
+
+	return nil
+}
+
+// checkHSTS returns a description of the violation if the given Strict-Transport-Security header does not meet the policy.
+func (c *SecurityHeadersChecker) checkHSTS(hsts string) string {
+	if hsts == "" {
+		return "Strict-Transport-Security: expected header to be set"
+	}
+
+	minMaxAge := c.HSTSMinMaxAge
+	if minMaxAge == 0 {
+		minMaxAge = DefaultHSTSMinMaxAge
+	}
+
+	maxAge := -1
+	includeSubDomains := false
+	for _, directive := range strings.Split(hsts, ";") {
+		directive = strings.TrimSpace(directive)
+		switch {
+		case strings.EqualFold(directive, "includeSubDomains"):
+			includeSubDomains = true
+		case len(directive) > 8 && strings.EqualFold(directive[:8], "max-age="):
+			if seconds, err := strconv.Atoi(strings.Trim(directive[8:], `"`)); err == nil {
+				maxAge = seconds
+			}
+		}
+	}
+
+	switch {
+	case maxAge < 0:
+		return fmt.Sprintf("Strict-Transport-Security: expected a max-age, got %q", hsts)
+	case time.Duration(maxAge)*time.Second < minMaxAge:
+		return fmt.Sprintf("Strict-Transport-Security: expected max-age of at least %d, got %d", int64(minMaxAge/time.Second), maxAge)
+	case c.HSTSIncludeSubDomains && !includeSubDomains:
+		return fmt.Sprintf("Strict-Transport-Security: expected includeSubDomains, got %q", hsts)
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package check_test
+
+import (
+	"context"
+	"github.com/tomwright/apitestr/check"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestSecurityHeadersChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	secure := func() http.Header {
+		return http.Header{
+			"Strict-Transport-Security": {"max-age=31536000; includeSubDomains"},
+			"X-Content-Type-Options":    {"nosniff"},
+			"X-Frame-Options":           {"DENY"},
+			"Content-Security-Policy":   {"default-src 'self'"},
+			"Server":                    {"nginx"},
+			"Referrer-Policy":           {"no-referrer"},
+		}
+	}
+
+	tests := [...]struct {
+		desc        string
+		header      func() http.Header
+		checker     *check.SecurityHeadersChecker
+		expectedErr string
+	}{
+		{
+			desc:    "default policy",
+			header:  secure,
+			checker: &check.SecurityHeadersChecker{},
+		},
+		{
+			desc: "frame ancestors",
+			header: func() http.Header {
+				h := secure()
+				h.Del("X-Frame-Options")
+				h.Set("Content-Security-Policy", "default-src 'self'; frame-ancestors 'none'")
+				return h
+			},
+			checker: &check.SecurityHeadersChecker{},
+		},
+		{
+			desc: "every violation",
+			header: func() http.Header {
+				return http.Header{
+					"Strict-Transport-Security": {"max-age=3600"},
+					"X-Frame-Options":           {"ALLOW-FROM https://example.com"},
+					"Server":                    {"Apache/2.4.41 (Ubuntu)"},
+					"X-Powered-By":              {"PHP/7.4.3"},
+				}
+			},
+			checker: &check.SecurityHeadersChecker{},
+			expectedErr: "insecure headers: 6 violation(s):\n" +
+				"  - Strict-Transport-Security: expected max-age of at least 31536000, got 3600\n" +
+				"  - X-Content-Type-Options: expected nosniff, got \"\"\n" +
+				"  - X-Frame-Options: expected DENY or SAMEORIGIN, or a Content-Security-Policy with frame-ancestors, got \"ALLOW-FROM https://example.com\"\n" +
+				"  - Content-Security-Policy: expected a policy, got none\n" +
+				"  - Server: expected no version number, got \"Apache/2.4.41 (Ubuntu)\"\n" +
+				"  - X-Powered-By: expected no version number, got \"PHP/7.4.3\"",
+		},
+		{
+			desc: "customised policy",
+			header: func() http.Header {
+				h := secure()
+				h.Set("Strict-Transport-Security", "max-age=3600")
+				h.Del("Content-Security-Policy")
+				h.Set("X-Powered-By", "Express")
+				return h
+			},
+			checker: &check.SecurityHeadersChecker{
+				HSTSMinMaxAge: time.Hour,
+				IgnoreCSP:     true,
+				Required: map[string]*regexp.Regexp{
+					"referrer-policy":    regexp.MustCompile(`^(no-referrer|strict-origin)$`),
+					"Permissions-Policy": nil,
+				},
+				Forbidden: []string{"x-powered-by"},
+			},
+			expectedErr: "insecure headers: 2 violation(s):\n" +
+				"  - Permissions-Policy: expected header to be set\n" +
+				"  - X-Powered-By: expected header not to be set, got \"Express\"",
+		},
+		{
+			desc: "hsts include sub domains",
+			header: func() http.Header {
+				h := secure()
+				h.Set("Strict-Transport-Security", "max-age=63072000")
+				return h
+			},
+			checker:     &check.SecurityHeadersChecker{HSTSIncludeSubDomains: true},
+			expectedErr: "insecure headers: 1 violation(s):\n  - Strict-Transport-Security: expected includeSubDomains, got \"max-age=63072000\"",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			response := responseWithBody("")
+			response.Header = tc.header()
+
+			err := tc.checker.Check(context.Background(), response)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Errorf("expected error but got none")
+				return
+			}
+			if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
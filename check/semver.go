@@ -0,0 +1,86 @@
+package check
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed semantic version.
+type semver struct {
+	parts      [3]int
+	prerelease []string
+}
+
+// parseSemver parses a semantic version such as `v1.2.3-beta.1+build`.
+// Missing minor and patch numbers default to 0, so `2.3` is treated as `2.3.0`.
+func parseSemver(str string) (*semver, error) {
+	v := &semver{}
+	s := strings.TrimPrefix(strings.TrimSpace(str), "v")
+	if i := strings.Index(s, "+"); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.Index(s, "-"); i >= 0 {
+		v.prerelease = strings.Split(s[i+1:], ".")
+		s = s[:i]
+	}
+	nums := strings.Split(s, ".")
+	if s == "" || len(nums) > 3 {
+		return nil, fmt.Errorf("invalid semantic version `%s`", str)
+	}
+	for i, n := range nums {
+		num, err := strconv.Atoi(n)
+		if err != nil || num < 0 {
+			return nil, fmt.Errorf("invalid semantic version `%s`", str)
+		}
+		v.parts[i] = num
+	}
+	return v, nil
+}
+
+// compare returns -1, 0 or 1 if v is less than, equal to or greater than o, following semver precedence rules.
+func (v *semver) compare(o *semver) int {
+	for i := range v.parts {
+		if c := compareInts(v.parts[i], o.parts[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.prerelease) == 0 && len(o.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(o.prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.prerelease) && i < len(o.prerelease); i++ {
+		a, b := v.prerelease[i], o.prerelease[i]
+		aNum, aErr := strconv.Atoi(a)
+		bNum, bErr := strconv.Atoi(b)
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareInts(aNum, bNum)
+		case aErr == nil:
+			c = -1
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(a, b)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(v.prerelease), len(o.prerelease))
+}
+
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,263 @@
+package check
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	updateSnapshotsCtxKey ctxKey = "updateSnapshots"
+
+	// SnapshotIgnoredValue is written to snapshot files in place of ignored values.
+	SnapshotIgnoredValue = "<ignored>"
+)
+
+// ContextWithUpdateSnapshots returns a context that tells snapshot checks to write the actual response to the snapshot file instead of comparing against it.
+func ContextWithUpdateSnapshots(ctx context.Context, update bool) context.Context {
+	return context.WithValue(ctx, updateSnapshotsCtxKey, update)
+}
+
+// UpdateSnapshotsFromContext returns true if snapshot files should be updated.
+func UpdateSnapshotsFromContext(ctx context.Context) bool {
+	update, _ := ctx.Value(updateSnapshotsCtxKey).(bool)
+	return update
+}
+
+// SnapshotMissingError is returned when a snapshot file does not exist.
+type SnapshotMissingError struct {
+	// File is the path to the snapshot file.
+	File string
+}
+
+// Error returns an error string.
+func (e *SnapshotMissingError) Error() string {
+	return fmt.Sprintf("snapshot %v does not exist: run with snapshot updates enabled to create it", e.File)
+}
+
+// SnapshotMismatchError is returned when a check fails.
+type SnapshotMismatchError struct {
+	// File is the path to the snapshot file.
+	File string
+	// Differences contains each difference between the snapshot and the response.
+	Differences JSONDiff
+}
+
+// Error returns an error string.
+func (e *SnapshotMismatchError) Error() string {
+	return e.render(false)
+}
+
+// ColourError returns an error string with a coloured diff.
+func (e *SnapshotMismatchError) ColourError() string {
+	return e.render(true)
+}
+
+func (e *SnapshotMismatchError) render(colour bool) string {
+	return fmt.Sprintf("response does not match snapshot %v: %d difference(s):\n%s", e.File, len(e.Differences), e.Differences.Render(colour))
+}
+
+// SnapshotChecker compares the http response against the snapshot stored in `File`.
+// The body is always compared. If `Status` is true the status code is compared, and any headers named in `Headers` are compared.
+// `Ignore` contains gjson style paths within the snapshot, e.g. `body.updatedAt` or `body.items.#.id`, that are not compared.
+// Pages after the first page of a paginated test are compared against their own file, e.g. `_test.0.snapshot.page-2.json`.
+type SnapshotChecker struct {
+	File    string
+	Status  bool
+	Headers []string
+	Ignore  []string
+}
+
+// Check performs the Snapshot check
+func (c *SnapshotChecker) Check(ctx context.Context, response *http.Response) error {
+	actual, err := c.snapshot(response)
+	if err != nil {
+		return err
+	}
+
+	file := c.file(ctx)
+
+	if UpdateSnapshotsFromContext(ctx) {
+		return c.write(file, actual)
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &SnapshotMissingError{File: file}
+		}
+		return fmt.Errorf("could not read snapshot: %w", err)
+	}
+
+	var expected interface{}
+	if err := json.Unmarshal(data, &expected); err != nil {
+		return fmt.Errorf("could not unmarshal snapshot %s: %w", file, err)
+	}
+
+	for _, path := range c.Ignore {
+		segments := splitJSONPath(path)
+		expected = removeJSONPath(expected, segments, false)
+		actual = removeJSONPath(actual, segments, false)
+	}
+
+	if diff := DiffJSON(expected, actual); len(diff) > 0 {
+		return &SnapshotMismatchError{
+			File:        file,
+			Differences: diff,
+		}
+	}
+
+	return nil
+}
+
+// file returns the path of the snapshot file for the page being checked.
+func (c *SnapshotChecker) file(ctx context.Context) string {
+	page := PageFromContext(ctx)
+	if page == 1 {
+		return c.File
+	}
+	ext := filepath.Ext(c.File)
+	return fmt.Sprintf("%s.page-%d%s", strings.TrimSuffix(c.File, ext), page, ext)
+}
+
+// snapshot builds the snapshot document for the given response.
+// JSON bodies are stored under `body`, any other body is stored as a string under `text`.
+func (c *SnapshotChecker) snapshot(response *http.Response) (interface{}, error) {
+	body, err := readResponseBody(response)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make(map[string]interface{})
+
+	if c.Status {
+		snapshot["status"] = float64(response.StatusCode)
+	}
+
+	if len(c.Headers) > 0 {
+		headers := make(map[string]interface{}, len(c.Headers))
+		for _, name := range c.Headers {
+			if values, ok := response.Header[http.CanonicalHeaderKey(name)]; ok {
+				headers[name] = strings.Join(values, ", ")
+			}
+		}
+		snapshot["headers"] = headers
+	}
+
+	var jsonBody interface{}
+	if len(body) > 0 && json.Unmarshal(body, &jsonBody) == nil {
+		snapshot["body"] = jsonBody
+	} else {
+		snapshot["text"] = string(body)
+	}
+
+	return snapshot, nil
+}
+
+// write writes the given snapshot to the given file, replacing ignored values with a placeholder.
+func (c *SnapshotChecker) write(file string, snapshot interface{}) error {
+	for _, path := range c.Ignore {
+		snapshot = removeJSONPath(snapshot, splitJSONPath(path), true)
+	}
+
+	// html escaping is disabled so that placeholders such as `<ignored>` are readable
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(snapshot); err != nil {
+		return fmt.Errorf("could not marshal snapshot: %w", err)
+	}
+
+	if dir := filepath.Dir(file); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("could not create snapshot directory: %w", err)
+		}
+	}
+
+	if err := ioutil.WriteFile(file, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("could not write snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// splitJSONPath splits a gjson style path into its segments, taking escaped characters into account.
+func splitJSONPath(path string) []string {
+	segments := make([]string, 0)
+	var current strings.Builder
+	escaped := false
+	for _, r := range path {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '.':
+			segments = append(segments, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(segments, current.String())
+}
+
+// removeJSONPath removes the value found at the given path segments. A segment of `#` or `*` matches every element or key.
+// If replace is true the value is replaced with SnapshotIgnoredValue rather than being removed.
+// Array elements selected by index are always replaced so that the indexes of the remaining elements don't change.
+func removeJSONPath(val interface{}, segments []string, replace bool) interface{} {
+	if len(segments) == 0 {
+		return val
+	}
+	segment, rest := segments[0], segments[1:]
+	wildcard := segment == "#" || segment == "*"
+
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if !wildcard && k != segment {
+				continue
+			}
+			if len(rest) > 0 {
+				v[k] = removeJSONPath(child, rest, replace)
+			} else if replace {
+				v[k] = SnapshotIgnoredValue
+			} else {
+				delete(v, k)
+			}
+		}
+	case []interface{}:
+		if wildcard {
+			for i, child := range v {
+				if len(rest) > 0 {
+					v[i] = removeJSONPath(child, rest, replace)
+				} else if replace {
+					v[i] = SnapshotIgnoredValue
+				}
+			}
+			if len(rest) == 0 && !replace {
+				return []interface{}{}
+			}
+			return v
+		}
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= len(v) {
+			return v
+		}
+		if len(rest) > 0 {
+			v[index] = removeJSONPath(v[index], rest, replace)
+		} else {
+			v[index] = SnapshotIgnoredValue
+		}
+	}
+	return val
+}
@@ -0,0 +1,166 @@
+package check_test
+
+import (
+	"context"
+	"errors"
+	"github.com/tomwright/apitestr/check"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSnapshotChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "apitestr")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "snapshots", "_test.0.snapshot.json")
+	checker := &check.SnapshotChecker{
+		File:    file,
+		Status:  true,
+		Headers: []string{"Content-Type"},
+		Ignore:  []string{"body.updatedAt", "body.items.#.id"},
+	}
+	ctx := context.Background()
+	updateCtx := check.ContextWithUpdateSnapshots(ctx, true)
+
+	response := func(name string, updatedAt string) func() error {
+		return func() error {
+			r := responseWithBody(`{"name":"` + name + `","updatedAt":"` + updatedAt + `","items":[{"id":1,"title":"a"},{"id":2,"title":"b"}]}`)
+			r.Header.Set("Content-Type", "application/json")
+			return checker.Check(ctx, r)
+		}
+	}
+
+	// missing
+	var missingErr *check.SnapshotMissingError
+	if err := response("Tom", "1")(); !errors.As(err, &missingErr) {
+		t.Fatalf("expected snapshot missing error, got %v", err)
+	}
+	if exp, got := file, missingErr.File; exp != got {
+		t.Errorf("expected missing file %s, got %s", exp, got)
+	}
+
+	// create
+	r := responseWithBody(`{"name":"Tom","updatedAt":"1","items":[{"id":1,"title":"a"},{"id":2,"title":"b"}]}`)
+	r.Header.Set("Content-Type", "application/json")
+	if err := checker.Check(updateCtx, r); err != nil {
+		t.Fatalf("unexpected error creating snapshot: %s", err)
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatalf("could not read snapshot: %s", err)
+	}
+	expSnapshot := `{
+  "body": {
+    "items": [
+      {
+        "id": "<ignored>",
+        "title": "a"
+      },
+      {
+        "id": "<ignored>",
+        "title": "b"
+      }
+    ],
+    "name": "Tom",
+    "updatedAt": "<ignored>"
+  },
+  "headers": {
+    "Content-Type": "application/json"
+  },
+  "status": 200
+}
+`
+	if exp, got := expSnapshot, string(data); exp != got {
+		t.Errorf("expected snapshot:\n%s\ngot:\n%s", exp, got)
+	}
+
+	// compare, with ignored values changing
+	if err := response("Tom", "2")(); err != nil {
+		t.Errorf("unexpected error comparing snapshot: %s", err)
+	}
+
+	// mismatch
+	var mismatchErr *check.SnapshotMismatchError
+	if err := response("Jim", "2")(); !errors.As(err, &mismatchErr) {
+		t.Fatalf("expected snapshot mismatch error, got %v", err)
+	}
+	if exp, got := "~ body.name: expected \"Tom\", got \"Jim\"", mismatchErr.Differences.String(); exp != got {
+		t.Errorf("expected diff `%s`, got `%s`", exp, got)
+	}
+
+	// update
+	r = responseWithBody(`{"name":"Jim","updatedAt":"3","items":[]}`)
+	r.Header.Set("Content-Type", "application/json")
+	if err := checker.Check(updateCtx, r); err != nil {
+		t.Fatalf("unexpected error updating snapshot: %s", err)
+	}
+	r = responseWithBody(`{"name":"Jim","updatedAt":"4","items":[]}`)
+	r.Header.Set("Content-Type", "application/json")
+	if err := checker.Check(ctx, r); err != nil {
+		t.Errorf("unexpected error comparing updated snapshot: %s", err)
+	}
+}
+
+func TestSnapshotChecker_Check_Text(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "apitestr")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	checker := &check.SnapshotChecker{File: filepath.Join(dir, "_test.0.snapshot.json")}
+
+	if err := checker.Check(check.ContextWithUpdateSnapshots(context.Background(), true), responseWithBody("hello")); err != nil {
+		t.Fatalf("unexpected error creating snapshot: %s", err)
+	}
+
+	var mismatchErr *check.SnapshotMismatchError
+	if err := checker.Check(context.Background(), responseWithBody("world")); !errors.As(err, &mismatchErr) {
+		t.Fatalf("expected snapshot mismatch error, got %v", err)
+	}
+	if exp, got := "~ text: expected \"hello\", got \"world\"", mismatchErr.Differences.String(); exp != got {
+		t.Errorf("expected diff `%s`, got `%s`", exp, got)
+	}
+}
+
+func TestSnapshotChecker_Check_Pages(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "apitestr")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	checker := &check.SnapshotChecker{File: filepath.Join(dir, "_test.0.snapshot.json")}
+	updateCtx := check.ContextWithUpdateSnapshots(context.Background(), true)
+
+	if err := checker.Check(updateCtx, responseWithBody(`{"page":1}`)); err != nil {
+		t.Fatalf("unexpected error creating page 1 snapshot: %s", err)
+	}
+	if err := checker.Check(check.ContextWithPage(updateCtx, 2), responseWithBody(`{"page":2}`)); err != nil {
+		t.Fatalf("unexpected error creating page 2 snapshot: %s", err)
+	}
+
+	for _, file := range []string{"_test.0.snapshot.json", "_test.0.snapshot.page-2.json"} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			t.Errorf("expected snapshot file %s to exist: %s", file, err)
+		}
+	}
+
+	if err := checker.Check(context.Background(), responseWithBody(`{"page":1}`)); err != nil {
+		t.Errorf("unexpected error comparing page 1: %s", err)
+	}
+	if err := checker.Check(check.ContextWithPage(context.Background(), 2), responseWithBody(`{"page":2}`)); err != nil {
+		t.Errorf("unexpected error comparing page 2: %s", err)
+	}
+}
@@ -0,0 +1,29 @@
+package check
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	timingsCtxKey ctxKey = "timings"
+)
+
+// Timings contains timing information about a request.
+type Timings struct {
+	// FirstByte is the time between sending the request and receiving the first byte of the response.
+	FirstByte time.Duration
+	// Total is the time between sending the request and reading the full response body.
+	Total time.Duration
+}
+
+// ContextWithTimings embeds the given request timings in the context.
+func ContextWithTimings(ctx context.Context, timings Timings) context.Context {
+	return context.WithValue(ctx, timingsCtxKey, timings)
+}
+
+// TimingsFromContext returns the request timings from the context.
+func TimingsFromContext(ctx context.Context) (Timings, bool) {
+	timings, ok := ctx.Value(timingsCtxKey).(Timings)
+	return timings, ok
+}
@@ -0,0 +1,19 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Violations describes each way in which a response breaks a set of rules, so that they can all be reported at once.
+type Violations []string
+
+// Add adds a violation described by the given format and args.
+func (v *Violations) Add(format string, args ...interface{}) {
+	*v = append(*v, fmt.Sprintf(format, args...))
+}
+
+// String returns the number of violations, followed by each violation on a new line.
+func (v Violations) String() string {
+	return fmt.Sprintf("%d violation(s):\n  - %s", len(v), strings.Join(v, "\n  - "))
+}
@@ -0,0 +1,112 @@
+package check
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+	"math"
+	"strconv"
+)
+
+// XMLQueryValueMissingError is returned when a check fails.
+type XMLQueryValueMissingError struct {
+	// Query is the XPath query.
+	Query string
+}
+
+// Error returns an error string.
+func (e *XMLQueryValueMissingError) Error() string {
+	return fmt.Sprintf("value at %v is missing", e.Query)
+}
+
+// CompileXMLQuery compiles the given XPath query using the given namespace prefix mappings.
+func CompileXMLQuery(query string, namespaces map[string]string) (*xpath.Expr, error) {
+	var expr *xpath.Expr
+	var err error
+	if len(namespaces) > 0 {
+		expr, err = xpath.CompileWithNS(query, namespaces)
+	} else {
+		expr, err = xpath.Compile(query)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not compile xpath query `%s`: %w", query, err)
+	}
+	return expr, nil
+}
+
+// evaluateXMLQuery parses the body as XML and evaluates the given XPath query against it.
+// If the query selects nodes, the string value of the first node is returned.
+// The bool returned is false if the query selected no nodes.
+func evaluateXMLQuery(body []byte, query string, namespaces map[string]string) (string, bool, error) {
+	res, err := evaluateXMLQueryResult(body, query, namespaces)
+	if err != nil {
+		return "", false, err
+	}
+
+	switch r := res.(type) {
+	case *xpath.NodeIterator:
+		if !r.MoveNext() {
+			return "", false, nil
+		}
+		return r.Current().Value(), true, nil
+	default:
+		return fmtXMLQueryResult(r), true, nil
+	}
+}
+
+// evaluateXMLQueryExists parses the body as XML and evaluates the given XPath query against it.
+// The bool returned is the XPath boolean value of the result: it is false if the query selected no nodes,
+// or returned false, zero or an empty string.
+// If it is true, the string value of the first node, or the result, is returned.
+func evaluateXMLQueryExists(body []byte, query string, namespaces map[string]string) (string, bool, error) {
+	res, err := evaluateXMLQueryResult(body, query, namespaces)
+	if err != nil {
+		return "", false, err
+	}
+
+	switch r := res.(type) {
+	case *xpath.NodeIterator:
+		if !r.MoveNext() {
+			return "", false, nil
+		}
+		return r.Current().Value(), true, nil
+	case bool:
+		return fmtXMLQueryResult(r), r, nil
+	case float64:
+		return fmtXMLQueryResult(r), r != 0 && !math.IsNaN(r), nil
+	case string:
+		return r, r != "", nil
+	default:
+		return fmtXMLQueryResult(r), true, nil
+	}
+}
+
+// evaluateXMLQueryResult parses the body as XML and returns the result of the given XPath query.
+func evaluateXMLQueryResult(body []byte, query string, namespaces map[string]string) (interface{}, error) {
+	expr, err := CompileXMLQuery(query, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := xmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse xml response: %w", err)
+	}
+
+	return expr.Evaluate(xmlquery.CreateXPathNavigator(doc)), nil
+}
+
+// fmtXMLQueryResult returns the string value of a string, number or boolean XPath result.
+func fmtXMLQueryResult(res interface{}) string {
+	switch r := res.(type) {
+	case string:
+		return r
+	case float64:
+		return strconv.FormatFloat(r, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(r)
+	default:
+		return fmt.Sprintf("%v", r)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"github.com/tomwright/apitestr"
+	"github.com/tomwright/apitestr/check"
 	"github.com/tomwright/apitestr/parse"
 	"log"
 	"net/http"
@@ -23,18 +24,34 @@ func main() {
 	var testDirs string
 	var maxConcurrentTests int
 	var httpTimeout int
+	var colour bool
+	var updateSnapshots bool
+	var cookieJar string
+	var reportAllFailures bool
 
 	flag.StringVar(&baseAddr, "base", "", "the base address used in http requests")
 	flag.StringVar(&testDirs, "tests", "", "the directory that tests are located in")
 	flag.IntVar(&maxConcurrentTests, "maxConcurrentTests", defaultMaxConcurrentTests, "the maximum number of tests that can be run concurrently")
 	flag.IntVar(&httpTimeout, "httpTimeout", defaultHTTPTimeout, "the http timeout duration in seconds")
+	flag.BoolVar(&reportAllFailures, "reportAllFailures", false, "run every check in a test and report all failures, rather than stopping at the first failure")
+	flag.StringVar(&cookieJar, "cookieJar", "", "share cookies between tests in the same `suite` or `group`. cookies are not stored if empty")
+	flag.BoolVar(&updateSnapshots, "update-snapshots", false, "write the actual responses to snapshot files instead of comparing against them")
+	flag.BoolVar(&colour, "colour", false, "use ANSI colours when logging failures such as JSON diffs")
 
 	flag.Parse()
 
 	logger := log.New(os.Stderr, "", log.LstdFlags)
 
+	switch apitestr.CookieJarScope(cookieJar) {
+	case apitestr.CookieJarNone, apitestr.CookieJarSuite, apitestr.CookieJarGroup:
+	default:
+		logger.Printf("invalid cookieJar value `%s`: expected `suite` or `group`", cookieJar)
+		os.Exit(1)
+	}
+
 	ctx := context.Background()
 	ctx = apitestr.ContextWithBaseURL(ctx, baseAddr)
+	ctx = check.ContextWithUpdateSnapshots(ctx, updateSnapshots)
 
 	tests := make([]*apitestr.Test, 0)
 
@@ -66,6 +83,9 @@ func main() {
 		MaxConcurrentTests:   maxConcurrentTests,
 		IgnoreGroupOnFailure: false,
 		IgnoreAllOnFailure:   true,
+		ReportAllFailures:    reportAllFailures,
+		CookieJar:            apitestr.CookieJarScope(cookieJar),
+		ColourOutput:         colour,
 	}, tests...)
 
 	if logger != nil {
@@ -0,0 +1,91 @@
+package apitestr
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"github.com/tomwright/apitestr/check"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// decodeResponseBody decodes the given response body according to the Content-Encoding header.
+// gzip and deflate encodings are decoded, and any other encoding is left as is.
+// Empty bodies, and the responses to HEAD requests and 204 and 304 responses, are never decoded as they have no content.
+func decodeResponseBody(response *http.Response, body []byte) ([]byte, check.Compression, error) {
+	compression := check.Compression{
+		Encoding:         response.Header.Get("Content-Encoding"),
+		CompressedSize:   len(body),
+		DecompressedSize: len(body),
+	}
+
+	if len(body) == 0 ||
+		response.StatusCode == http.StatusNoContent ||
+		response.StatusCode == http.StatusNotModified ||
+		(response.Request != nil && response.Request.Method == http.MethodHead) {
+		return body, compression, nil
+	}
+
+	// go's transport decodes gzip responses itself if it requested them, and removes the header
+	if response.Uncompressed {
+		compression.Encoding = "gzip"
+		compression.CompressedSize = -1
+		return body, compression, nil
+	}
+
+	encodings := strings.Split(compression.Encoding, ",")
+	// encodings are listed in the order they were applied, so decode them in reverse
+	for i := len(encodings) - 1; i >= 0; i-- {
+		var err error
+		switch encoding := strings.ToLower(strings.TrimSpace(encodings[i])); encoding {
+		case "", "identity":
+			continue
+		case "gzip", "x-gzip":
+			body, err = decodeBody(body, func(r io.Reader) (io.ReadCloser, error) {
+				return gzip.NewReader(r)
+			})
+		case "deflate":
+			body, err = decodeDeflateBody(body)
+		default:
+			compression.DecompressedSize = len(body)
+			return body, compression, nil
+		}
+		if err != nil {
+			return nil, compression, fmt.Errorf("could not decode %s response body: %w", strings.TrimSpace(encodings[i]), err)
+		}
+	}
+
+	compression.DecompressedSize = len(body)
+	return body, compression, nil
+}
+
+// decodeDeflateBody decodes a deflate encoded body.
+// The deflate encoding should use the zlib format, but some servers send raw deflate data so that is used as a fallback.
+func decodeDeflateBody(body []byte) ([]byte, error) {
+	decoded, err := decodeBody(body, zlib.NewReader)
+	if err == nil {
+		return decoded, nil
+	}
+	return decodeBody(body, func(r io.Reader) (io.ReadCloser, error) {
+		return flate.NewReader(r), nil
+	})
+}
+
+func decodeBody(body []byte, newReader func(io.Reader) (io.ReadCloser, error)) ([]byte, error) {
+	r, err := newReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Close(); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
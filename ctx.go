@@ -11,6 +11,8 @@ const (
 	ctxBaseURLKey      ctxKey = "baseUrl"
 	ctxCustomCheckKey  ctxKey = "customBodyCheck_"
 	ctxRequestInitFunc ctxKey = "requestInitFunc_"
+	ctxTestFilePathKey ctxKey = "testFilePath"
+	ctxReportAllKey    ctxKey = "reportAllFailures"
 )
 
 // ContextWithBaseURL stores the given base URL in the context
@@ -63,3 +65,37 @@ func RequestInitFuncFromContext(ctx context.Context, initFuncID string) RequestI
 	}
 	return nil
 }
+
+// ContextWithTestFilePath stores the path of the test file currently being parsed in the context
+func ContextWithTestFilePath(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, ctxTestFilePathKey, path)
+}
+
+// TestFilePathFromContext returns the path of the test file currently being parsed, as stored in the given context
+func TestFilePathFromContext(ctx context.Context) string {
+	val := ctx.Value(ctxTestFilePathKey)
+	if val == nil {
+		return ""
+	}
+	if str, ok := val.(string); ok {
+		return str
+	}
+	return ""
+}
+
+// ContextWithReportAllFailures stores whether or not every check should be run and all failures reported, rather than stopping at the first failure
+func ContextWithReportAllFailures(ctx context.Context, reportAll bool) context.Context {
+	return context.WithValue(ctx, ctxReportAllKey, reportAll)
+}
+
+// ReportAllFailuresFromContext returns true if every check should be run and all failures reported, as stored in the given context
+func ReportAllFailuresFromContext(ctx context.Context) bool {
+	val := ctx.Value(ctxReportAllKey)
+	if val == nil {
+		return false
+	}
+	if reportAll, ok := val.(bool); ok {
+		return reportAll
+	}
+	return false
+}
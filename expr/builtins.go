@@ -0,0 +1,173 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// builtins contains the functions available to every expression.
+var builtins map[string]Func
+
+func init() {
+	builtins = map[string]Func{
+		"len":        builtinLen,
+		"number":     builtinNumber,
+		"string":     builtinString,
+		"lower":      stringFunc("lower", strings.ToLower),
+		"upper":      stringFunc("upper", strings.ToUpper),
+		"contains":   builtinContains,
+		"startsWith": stringPredicate("startsWith", strings.HasPrefix),
+		"endsWith":   stringPredicate("endsWith", strings.HasSuffix),
+		"matches":    builtinMatches,
+		"abs":        builtinAbs,
+		"min":        numberReducer("min", math.Min),
+		"max":        numberReducer("max", math.Max),
+		"type":       builtinType,
+	}
+}
+
+func expectArgs(name string, args []interface{}, count int) error {
+	if len(args) != count {
+		return fmt.Errorf("%s expects %d argument(s), got %d", name, count, len(args))
+	}
+	return nil
+}
+
+func builtinLen(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("len", args, 1); err != nil {
+		return nil, err
+	}
+	switch v := args[0].(type) {
+	case string:
+		return float64(utf8.RuneCountInString(v)), nil
+	case []interface{}:
+		return float64(len(v)), nil
+	case map[string]interface{}:
+		return float64(len(v)), nil
+	}
+	return nil, fmt.Errorf("len cannot be used with %s", typeName(args[0]))
+}
+
+func builtinNumber(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("number", args, 1); err != nil {
+		return nil, err
+	}
+	switch v := args[0].(type) {
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return float64(1), nil
+		}
+		return float64(0), nil
+	case string:
+		num, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %q to a number", v)
+		}
+		return num, nil
+	}
+	return nil, fmt.Errorf("cannot convert %s to a number", typeName(args[0]))
+}
+
+func builtinString(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("string", args, 1); err != nil {
+		return nil, err
+	}
+	if s, ok := args[0].(string); ok {
+		return s, nil
+	}
+	return fmtValue(args[0]), nil
+}
+
+func stringFunc(name string, fn func(string) string) Func {
+	return func(args ...interface{}) (interface{}, error) {
+		if err := expectArgs(name, args, 1); err != nil {
+			return nil, err
+		}
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("%s expects a string, got %s", name, typeName(args[0]))
+		}
+		return fn(s), nil
+	}
+}
+
+func stringPredicate(name string, fn func(string, string) bool) Func {
+	return func(args ...interface{}) (interface{}, error) {
+		if err := expectArgs(name, args, 2); err != nil {
+			return nil, err
+		}
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("%s expects a string, got %s", name, typeName(args[0]))
+		}
+		other, ok := args[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("%s expects a string, got %s", name, typeName(args[1]))
+		}
+		return fn(s, other), nil
+	}
+}
+
+func builtinContains(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("contains", args, 2); err != nil {
+		return nil, err
+	}
+	return contains(args[0], args[1])
+}
+
+func builtinMatches(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("matches", args, 2); err != nil {
+		return nil, err
+	}
+	return matches(args[0], args[1])
+}
+
+func builtinAbs(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("abs", args, 1); err != nil {
+		return nil, err
+	}
+	num, ok := args[0].(float64)
+	if !ok {
+		return nil, fmt.Errorf("abs expects a number, got %s", typeName(args[0]))
+	}
+	return math.Abs(num), nil
+}
+
+// numberReducer returns a function that reduces its arguments, or the elements of a single array argument, using fn.
+func numberReducer(name string, fn func(float64, float64) float64) Func {
+	return func(args ...interface{}) (interface{}, error) {
+		if len(args) == 1 {
+			if arr, ok := args[0].([]interface{}); ok {
+				args = arr
+			}
+		}
+		if len(args) == 0 {
+			return nil, fmt.Errorf("%s expects at least 1 number", name)
+		}
+		var res float64
+		for i, arg := range args {
+			num, ok := arg.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s expects numbers, got %s", name, typeName(arg))
+			}
+			if i == 0 {
+				res = num
+				continue
+			}
+			res = fn(res, num)
+		}
+		return res, nil
+	}
+}
+
+func builtinType(args ...interface{}) (interface{}, error) {
+	if err := expectArgs("type", args, 1); err != nil {
+		return nil, err
+	}
+	return typeName(args[0]), nil
+}
@@ -0,0 +1,394 @@
+package expr
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type evaluator struct {
+	source string
+	env    Env
+}
+
+// errorf returns an EvalError for the given node.
+func (e *evaluator) errorf(n node, format string, args ...interface{}) error {
+	start, end := n.span()
+	return &EvalError{
+		Expr: e.source[start:end],
+		Msg:  fmt.Sprintf(format, args...),
+	}
+}
+
+func (e *evaluator) eval(n node) (interface{}, error) {
+	switch n := n.(type) {
+	case *literalNode:
+		return n.value, nil
+
+	case *identNode:
+		val, ok := e.env[n.name]
+		if !ok {
+			return nil, e.errorf(n, "unknown variable `%s`", n.name)
+		}
+		if _, isFunc := val.(Func); isFunc {
+			return nil, e.errorf(n, "`%s` is a function", n.name)
+		}
+		return normalise(val), nil
+
+	case *memberNode:
+		obj, err := e.eval(n.object)
+		if err != nil {
+			return nil, err
+		}
+		return e.field(n, obj, n.name)
+
+	case *indexNode:
+		obj, err := e.eval(n.object)
+		if err != nil {
+			return nil, err
+		}
+		index, err := e.eval(n.index)
+		if err != nil {
+			return nil, err
+		}
+		if key, ok := index.(string); ok {
+			return e.field(n, obj, key)
+		}
+		arr, ok := obj.([]interface{})
+		if !ok {
+			return nil, e.errorf(n, "cannot index %s with %s", typeName(obj), typeName(index))
+		}
+		num, ok := index.(float64)
+		if !ok || num != math.Trunc(num) {
+			return nil, e.errorf(n, "array index must be an integer, got %s", fmtValue(index))
+		}
+		if num < 0 || int(num) >= len(arr) {
+			return nil, e.errorf(n, "index %d out of range for array of length %d", int(num), len(arr))
+		}
+		return arr[int(num)], nil
+
+	case *arrayNode:
+		arr := make([]interface{}, len(n.elements))
+		for i, element := range n.elements {
+			val, err := e.eval(element)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = val
+		}
+		return arr, nil
+
+	case *callNode:
+		args := make([]interface{}, len(n.args))
+		for i, arg := range n.args {
+			val, err := e.eval(arg)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = val
+		}
+		f, ok := builtins[n.name]
+		if !ok {
+			if envFunc, isFunc := e.env[n.name].(Func); isFunc {
+				f = envFunc
+			} else {
+				return nil, e.errorf(n, "unknown function `%s`", n.name)
+			}
+		}
+		res, err := f(args...)
+		if err != nil {
+			return nil, e.errorf(n, "%s", err)
+		}
+		return normalise(res), nil
+
+	case *unaryNode:
+		operand, err := e.eval(n.operand)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "!":
+			b, ok := operand.(bool)
+			if !ok {
+				return nil, e.errorf(n, "operator `!` requires a boolean, got %s", typeName(operand))
+			}
+			return !b, nil
+		case "-":
+			num, ok := operand.(float64)
+			if !ok {
+				return nil, e.errorf(n, "operator `-` requires a number, got %s", typeName(operand))
+			}
+			return -num, nil
+		}
+
+	case *binaryNode:
+		return e.evalBinary(n)
+	}
+
+	return nil, e.errorf(n, "unhandled expression")
+}
+
+// field returns the value of the given key in obj. Missing keys evaluate to null.
+func (e *evaluator) field(n node, obj interface{}, key string) (interface{}, error) {
+	m, ok := obj.(map[string]interface{})
+	if !ok {
+		return nil, e.errorf(n, "cannot access field `%s` of %s", key, typeName(obj))
+	}
+	return normalise(m[key]), nil
+}
+
+func (e *evaluator) evalBinary(n *binaryNode) (interface{}, error) {
+	left, err := e.eval(n.left)
+	if err != nil {
+		return nil, err
+	}
+
+	// logical operators short circuit
+	if n.op == "&&" || n.op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, e.errorf(n, "operator `%s` requires booleans, got %s", n.op, typeName(left))
+		}
+		if (n.op == "&&" && !l) || (n.op == "||" && l) {
+			return l, nil
+		}
+		right, err := e.eval(n.right)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, e.errorf(n, "operator `%s` requires booleans, got %s", n.op, typeName(right))
+		}
+		return r, nil
+	}
+
+	right, err := e.eval(n.right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return reflect.DeepEqual(left, right), nil
+	case "!=":
+		return !reflect.DeepEqual(left, right), nil
+
+	case "<", "<=", ">", ">=":
+		c, err := compare(left, right)
+		if err != nil {
+			return nil, e.errorf(n, "%s", err)
+		}
+		switch n.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+
+	case "in":
+		res, err := contains(right, left)
+		if err != nil {
+			return nil, e.errorf(n, "%s", err)
+		}
+		return res, nil
+
+	case "matches":
+		res, err := matches(left, right)
+		if err != nil {
+			return nil, e.errorf(n, "%s", err)
+		}
+		return res, nil
+
+	case "+":
+		if l, ok := left.(string); ok {
+			if r, ok := right.(string); ok {
+				return l + r, nil
+			}
+		}
+		if l, ok := left.([]interface{}); ok {
+			if r, ok := right.([]interface{}); ok {
+				return append(append(make([]interface{}, 0, len(l)+len(r)), l...), r...), nil
+			}
+		}
+		fallthrough
+
+	case "-", "*", "/", "%":
+		l, lOk := left.(float64)
+		r, rOk := right.(float64)
+		if !lOk || !rOk {
+			return nil, e.errorf(n, "operator `%s` cannot be used with %s and %s", n.op, typeName(left), typeName(right))
+		}
+		switch n.op {
+		case "+":
+			return l + r, nil
+		case "-":
+			return l - r, nil
+		case "*":
+			return l * r, nil
+		case "/":
+			if r == 0 {
+				return nil, e.errorf(n, "division by zero")
+			}
+			return l / r, nil
+		default:
+			if r == 0 {
+				return nil, e.errorf(n, "division by zero")
+			}
+			return math.Mod(l, r), nil
+		}
+	}
+
+	return nil, e.errorf(n, "unhandled operator `%s`", n.op)
+}
+
+// compare returns -1, 0 or 1 if a is less than, equal to or greater than b. Both must be numbers or both must be strings.
+func compare(a interface{}, b interface{}) (int, error) {
+	switch aVal := a.(type) {
+	case float64:
+		if bVal, ok := b.(float64); ok {
+			switch {
+			case aVal < bVal:
+				return -1, nil
+			case aVal > bVal:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		if bVal, ok := b.(string); ok {
+			return strings.Compare(aVal, bVal), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %s and %s", typeName(a), typeName(b))
+}
+
+// contains returns true if the haystack array contains the needle, the haystack string contains the needle substring, or the haystack object has the needle key.
+func contains(haystack interface{}, needle interface{}) (bool, error) {
+	switch h := haystack.(type) {
+	case []interface{}:
+		for _, v := range h {
+			if reflect.DeepEqual(v, needle) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case string:
+		n, ok := needle.(string)
+		if !ok {
+			return false, fmt.Errorf("cannot search for %s in string", typeName(needle))
+		}
+		return strings.Contains(h, n), nil
+	case map[string]interface{}:
+		n, ok := needle.(string)
+		if !ok {
+			return false, fmt.Errorf("cannot search for %s in object keys", typeName(needle))
+		}
+		_, ok = h[n]
+		return ok, nil
+	}
+	return false, fmt.Errorf("cannot search in %s", typeName(haystack))
+}
+
+func matches(value interface{}, pattern interface{}) (bool, error) {
+	str, ok := value.(string)
+	if !ok {
+		return false, fmt.Errorf("can only match strings, got %s", typeName(value))
+	}
+	p, ok := pattern.(string)
+	if !ok {
+		return false, fmt.Errorf("pattern must be a string, got %s", typeName(pattern))
+	}
+	r, err := regexp.Compile(p)
+	if err != nil {
+		return false, fmt.Errorf("invalid pattern: %s", err)
+	}
+	return r.MatchString(str), nil
+}
+
+// normalise converts Go values into the types used by expressions: nil, bool, float64, string, []interface{} and map[string]interface{}.
+func normalise(val interface{}) interface{} {
+	switch v := val.(type) {
+	case nil, bool, float64, string, []interface{}, map[string]interface{}, Func:
+		return v
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case []byte:
+		return string(v)
+	case []string:
+		res := make([]interface{}, len(v))
+		for i, s := range v {
+			res[i] = s
+		}
+		return res
+	case map[string]string:
+		res := make(map[string]interface{}, len(v))
+		for k, s := range v {
+			res[k] = s
+		}
+		return res
+	}
+
+	// fall back to a JSON round trip for any other types
+	b, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	var res interface{}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return res
+}
+
+// typeName returns the name of the type of the given expression value.
+func typeName(val interface{}) string {
+	switch val.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", val)
+}
+
+// FormatValue returns the given value formatted as it would be written in an expression.
+func FormatValue(val interface{}) string {
+	return fmtValue(normalise(val))
+}
+
+func fmtValue(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return "null"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	b, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return string(b)
+}
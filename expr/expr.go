@@ -0,0 +1,101 @@
+// Package expr implements a small, sandboxed expression language used to make assertions about http responses.
+//
+// Expressions can only read the values they are given and call a fixed set of functions, so they cannot loop, assign values or perform any I/O.
+//
+// Supported syntax:
+//   - literals: numbers, 'single' or "double" quoted strings, true, false, null and arrays such as [1, 2]
+//   - variables and field access: body.items[0].id, headers["Content-Type"]
+//   - arithmetic: + - * / %
+//   - comparison: == != < <= > >=
+//   - logic: && || !
+//   - membership: x in array, substring in string, key in object
+//   - regex: value matches "pattern"
+//   - function calls: len(body.items)
+package expr
+
+import (
+	"fmt"
+)
+
+// Func is a function that can be called from an expression.
+type Func func(args ...interface{}) (interface{}, error)
+
+// Env contains the variables and functions available to an expression.
+// Values of type Func can be called as functions. All other values are variables.
+type Env map[string]interface{}
+
+// SyntaxError is returned when an expression cannot be parsed.
+type SyntaxError struct {
+	// Pos is the byte offset in the expression at which the error occurred.
+	Pos int
+	// Msg describes the error.
+	Msg string
+}
+
+// Error returns an error string.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+// EvalError is returned when an expression cannot be evaluated.
+type EvalError struct {
+	// Expr is the part of the expression that could not be evaluated.
+	Expr string
+	// Msg describes the error.
+	Msg string
+}
+
+// Error returns an error string.
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("could not evaluate `%s`: %s", e.Expr, e.Msg)
+}
+
+// Program is a compiled expression.
+type Program struct {
+	source string
+	root   node
+}
+
+// Compile parses the given expression.
+func Compile(source string) (*Program, error) {
+	root, err := parse(source)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{source: source, root: root}, nil
+}
+
+// String returns the source of the expression.
+func (p *Program) String() string {
+	return p.source
+}
+
+// Eval evaluates the expression using the given environment.
+func (p *Program) Eval(env Env) (interface{}, error) {
+	e := &evaluator{source: p.source, env: env}
+	return e.eval(p.root)
+}
+
+// Operands evaluates the left and right operands of the expression if it is a comparison.
+// It can be used to explain why an expression evaluated to false.
+func (p *Program) Operands(env Env) (left interface{}, right interface{}, ok bool) {
+	b, isBinary := p.root.(*binaryNode)
+	if !isBinary {
+		return nil, nil, false
+	}
+	switch b.op {
+	case "==", "!=", "<", "<=", ">", ">=", "in", "matches":
+	default:
+		return nil, nil, false
+	}
+	e := &evaluator{source: p.source, env: env}
+	left, err := e.eval(b.left)
+	if err != nil {
+		return nil, nil, false
+	}
+	right, err = e.eval(b.right)
+	if err != nil {
+		return nil, nil, false
+	}
+	return left, right, true
+}
@@ -0,0 +1,145 @@
+package expr_test
+
+import (
+	"github.com/tomwright/apitestr/expr"
+	"reflect"
+	"testing"
+)
+
+func TestProgram_Eval(t *testing.T) {
+	t.Parallel()
+
+	env := expr.Env{
+		"status": 200,
+		"name":   "Tom",
+		"café":   "open",
+		"body": map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"id": float64(1), "tags": []interface{}{"a", "b"}},
+				map[string]interface{}{"id": float64(2), "tags": []interface{}{}},
+			},
+			"total": float64(2),
+		},
+		"headers": map[string]interface{}{"Content-Type": "application/json"},
+		"double": expr.Func(func(args ...interface{}) (interface{}, error) {
+			return args[0].(float64) * 2, nil
+		}),
+	}
+
+	tests := [...]struct {
+		expression  string
+		expected    interface{}
+		expectedErr string
+	}{
+		{expression: `status == 200`, expected: true},
+		{expression: `status >= 200 && status < 300`, expected: true},
+		{expression: `status == 201 || name == "Tom"`, expected: true},
+		{expression: `!(status == 200)`, expected: false},
+		{expression: `1 + 2 * 3`, expected: float64(7)},
+		{expression: `(1 + 2) * 3`, expected: float64(9)},
+		{expression: `10 % 4 - -1`, expected: float64(3)},
+		{expression: `'a' + "b"`, expected: "ab"},
+		{expression: `body.items[0].id`, expected: float64(1)},
+		{expression: `body["items"][1].id`, expected: float64(2)},
+		{expression: `body.missing`, expected: nil},
+		{expression: `body.missing == null`, expected: true},
+		{expression: `headers["Content-Type"] matches "^application/json"`, expected: true},
+		{expression: `matches(name, "^T")`, expected: true},
+		{expression: `matches(name, "^t") || name matches "m$"`, expected: true},
+		{expression: `café == "open"`, expected: true},
+		{expression: `"a" in body.items[0].tags`, expected: true},
+		{expression: `"om" in name`, expected: true},
+		{expression: `"total" in body`, expected: true},
+		{expression: `status in [200, 204]`, expected: true},
+		{expression: `len(body.items) == body.total`, expected: true},
+		{expression: `len(name)`, expected: float64(3)},
+		{expression: `lower(name) + upper(name)`, expected: "tomTOM"},
+		{expression: `startsWith(name, "T") && endsWith(name, "m")`, expected: true},
+		{expression: `number("1.5") + abs(-1)`, expected: 2.5},
+		{expression: `string(status)`, expected: "200"},
+		{expression: `min(3, 1, 2) + max([4, 5])`, expected: float64(6)},
+		{expression: `type(body.items)`, expected: "array"},
+		{expression: `double(status)`, expected: float64(400)},
+		{expression: `[1, 2] == [1, 2]`, expected: true},
+		{expression: `status == "200"`, expected: false},
+		{expression: `status == 201 && unknown`, expected: false},
+		{expression: `unknown`, expectedErr: "could not evaluate `unknown`: unknown variable `unknown`"},
+		{expression: `status && true`, expectedErr: "could not evaluate `status && true`: operator `&&` requires booleans, got number"},
+		{expression: `name.first`, expectedErr: "could not evaluate `name.first`: cannot access field `first` of string"},
+		{expression: `body.items[5]`, expectedErr: "could not evaluate `body.items[5]`: index 5 out of range for array of length 2"},
+		{expression: `status < "a"`, expectedErr: "could not evaluate `status < \"a\"`: cannot compare number and string"},
+		{expression: `1 / 0`, expectedErr: "could not evaluate `1 / 0`: division by zero"},
+		{expression: `nope(1)`, expectedErr: "could not evaluate `nope(1)`: unknown function `nope`"},
+		{expression: `é == 1`, expectedErr: "could not evaluate `é`: unknown variable `é`"},
+		{expression: `len(1, 2)`, expectedErr: "could not evaluate `len(1, 2)`: len expects 1 argument(s), got 2"},
+		{expression: `name matches "("`, expectedErr: "could not evaluate `name matches \"(\"`: invalid pattern: error parsing regexp: missing closing ): `(`"},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.expression, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := expr.Compile(tc.expression)
+			if err != nil {
+				t.Errorf("unexpected compile error: %s", err)
+				return
+			}
+
+			res, err := p.Eval(env)
+			if tc.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error but got none")
+					return
+				}
+				if exp, got := tc.expectedErr, err.Error(); exp != got {
+					t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			if !reflect.DeepEqual(tc.expected, res) {
+				t.Errorf("expected %v (%T), got %v (%T)", tc.expected, tc.expected, res, res)
+			}
+		})
+	}
+}
+
+func TestCompile_SyntaxError(t *testing.T) {
+	t.Parallel()
+
+	tests := [...]struct {
+		expression  string
+		expectedErr string
+	}{
+		{expression: `status ==`, expectedErr: "syntax error at position 9: unexpected end of expression"},
+		{expression: `(status == 200`, expectedErr: "syntax error at position 14: expected `)`, got end of expression"},
+		{expression: `"abc`, expectedErr: "syntax error at position 0: unterminated string"},
+		{expression: `status = 200`, expectedErr: "syntax error at position 7: unexpected character `=`"},
+		{expression: `body.`, expectedErr: "syntax error at position 5: expected field name after `.`, got end of expression"},
+		{expression: `len(1 2)`, expectedErr: "syntax error at position 6: expected `,` or `)`, got `2`"},
+		{expression: `status 200`, expectedErr: "syntax error at position 7: unexpected `200`"},
+		{expression: `status → 200`, expectedErr: "syntax error at position 7: unexpected character `→`"},
+		{expression: `matches == 1`, expectedErr: "syntax error at position 0: unexpected `matches`"},
+		{expression: `in(name)`, expectedErr: "syntax error at position 0: unexpected `in`"},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.expression, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := expr.Compile(tc.expression)
+			if err == nil {
+				t.Errorf("expected error but got none")
+				return
+			}
+			if exp, got := tc.expectedErr, err.Error(); exp != got {
+				t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	// text is the source text of the token. For strings it is the unquoted value.
+	text string
+	num  float64
+	pos  int
+	end  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("`%s`", t.text)
+}
+
+// operators contains every operator and punctuation token, longest first so that they are matched greedily.
+var operators = []string{
+	"&&", "||", "==", "!=", "<=", ">=",
+	"(", ")", "[", "]", ",", ".", "!", "<", ">", "+", "-", "*", "/", "%",
+}
+
+// lex splits the source into tokens.
+func lex(src string) ([]token, error) {
+	tokens := make([]token, 0)
+	i := 0
+
+lexLoop:
+	for i < len(src) {
+		r, width := utf8.DecodeRuneInString(src[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			i += width
+			continue lexLoop
+
+		case r >= '0' && r <= '9':
+			start := i
+			for i < len(src) && (isDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				i++
+				if i < len(src) && (src[i] == '+' || src[i] == '-') {
+					i++
+				}
+				for i < len(src) && isDigit(src[i]) {
+					i++
+				}
+			}
+			num, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, &SyntaxError{Pos: start, Msg: fmt.Sprintf("invalid number `%s`", src[start:i])}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[start:i], num: num, pos: start, end: i})
+			continue lexLoop
+
+		case r == '"' || r == '\'':
+			start := i
+			quote := src[i]
+			i++
+			var b strings.Builder
+			for {
+				if i >= len(src) {
+					return nil, &SyntaxError{Pos: start, Msg: "unterminated string"}
+				}
+				c := src[i]
+				if c == quote {
+					i++
+					break
+				}
+				if c == '\\' {
+					if i+1 >= len(src) {
+						return nil, &SyntaxError{Pos: start, Msg: "unterminated string"}
+					}
+					i++
+					switch src[i] {
+					case 'n':
+						b.WriteByte('\n')
+					case 't':
+						b.WriteByte('\t')
+					case 'r':
+						b.WriteByte('\r')
+					default:
+						b.WriteByte(src[i])
+					}
+					i++
+					continue
+				}
+				b.WriteByte(c)
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenString, text: b.String(), pos: start, end: i})
+			continue lexLoop
+
+		case r == '_' || r == '$' || unicode.IsLetter(r):
+			start := i
+			for i < len(src) {
+				c, cWidth := utf8.DecodeRuneInString(src[i:])
+				if c != '_' && c != '$' && !(c >= '0' && c <= '9') && !unicode.IsLetter(c) {
+					break
+				}
+				i += cWidth
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[start:i], pos: start, end: i})
+			continue lexLoop
+		}
+
+		for _, op := range operators {
+			if strings.HasPrefix(src[i:], op) {
+				tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i, end: i + len(op)})
+				i += len(op)
+				continue lexLoop
+			}
+		}
+
+		return nil, &SyntaxError{Pos: i, Msg: fmt.Sprintf("unexpected character `%c`", r)}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(src), end: len(src)}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
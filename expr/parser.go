@@ -0,0 +1,311 @@
+package expr
+
+import (
+	"fmt"
+)
+
+// maxDepth is the maximum nesting depth of an expression.
+const maxDepth = 100
+
+type node interface {
+	// span returns the start and end positions of the node in the source.
+	span() (int, int)
+}
+
+type position struct {
+	start int
+	end   int
+}
+
+func (p position) span() (int, int) {
+	return p.start, p.end
+}
+
+type literalNode struct {
+	position
+	value interface{}
+}
+
+type identNode struct {
+	position
+	name string
+}
+
+type memberNode struct {
+	position
+	object node
+	name   string
+}
+
+type indexNode struct {
+	position
+	object node
+	index  node
+}
+
+type callNode struct {
+	position
+	name string
+	args []node
+}
+
+type unaryNode struct {
+	position
+	op      string
+	operand node
+}
+
+type binaryNode struct {
+	position
+	op    string
+	left  node
+	right node
+}
+
+type arrayNode struct {
+	position
+	elements []node
+}
+
+// binaryPrecedence contains the precedence of each binary operator. Higher binds tighter.
+var binaryPrecedence = map[string]int{
+	"||":      1,
+	"&&":      2,
+	"==":      3,
+	"!=":      3,
+	"<":       4,
+	"<=":      4,
+	">":       4,
+	">=":      4,
+	"in":      4,
+	"matches": 4,
+	"+":       5,
+	"-":       5,
+	"*":       6,
+	"/":       6,
+	"%":       6,
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// parse parses the given source into a syntax tree.
+func parse(src string) (node, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	n, err := p.parseExpression(1)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %s", t)}
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(op string) (token, error) {
+	t := p.next()
+	if t.kind != tokenOperator || t.text != op {
+		return t, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("expected `%s`, got %s", op, t)}
+	}
+	return t, nil
+}
+
+// binaryOperator returns the binary operator at the current position, if any.
+func (p *parser) binaryOperator() (string, int, bool) {
+	t := p.peek()
+	if t.kind != tokenOperator && t.kind != tokenIdent {
+		return "", 0, false
+	}
+	precedence, ok := binaryPrecedence[t.text]
+	return t.text, precedence, ok
+}
+
+// parseExpression parses binary expressions using precedence climbing.
+func (p *parser) parseExpression(minPrecedence int) (node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, &SyntaxError{Pos: p.peek().pos, Msg: "expression is nested too deeply"}
+	}
+
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, precedence, ok := p.binaryOperator()
+		if !ok || precedence < minPrecedence {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseExpression(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+		start, _ := left.span()
+		_, end := right.span()
+		left = &binaryNode{position: position{start, end}, op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	if t.kind == tokenOperator && (t.text == "!" || t.text == "-") {
+		p.next()
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > maxDepth {
+			return nil, &SyntaxError{Pos: t.pos, Msg: "expression is nested too deeply"}
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		_, end := operand.span()
+		return &unaryNode{position: position{t.pos, end}, op: t.text, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		if t.kind != tokenOperator {
+			return n, nil
+		}
+		start, _ := n.span()
+
+		switch t.text {
+		case ".":
+			p.next()
+			name := p.next()
+			if name.kind != tokenIdent {
+				return nil, &SyntaxError{Pos: name.pos, Msg: fmt.Sprintf("expected field name after `.`, got %s", name)}
+			}
+			n = &memberNode{position: position{start, name.end}, object: n, name: name.text}
+
+		case "[":
+			p.next()
+			index, err := p.parseExpression(1)
+			if err != nil {
+				return nil, err
+			}
+			closing, err := p.expect("]")
+			if err != nil {
+				return nil, err
+			}
+			n = &indexNode{position: position{start, closing.end}, object: n, index: index}
+
+		default:
+			return n, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenNumber:
+		return &literalNode{position: position{t.pos, t.end}, value: t.num}, nil
+
+	case tokenString:
+		return &literalNode{position: position{t.pos, t.end}, value: t.text}, nil
+
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &literalNode{position: position{t.pos, t.end}, value: true}, nil
+		case "false":
+			return &literalNode{position: position{t.pos, t.end}, value: false}, nil
+		case "null":
+			return &literalNode{position: position{t.pos, t.end}, value: nil}, nil
+		}
+		next := p.peek()
+		isCall := next.kind == tokenOperator && next.text == "("
+		// `in` is only an operator, but `matches` is also a function when it is followed by `(`
+		if t.text == "in" || (t.text == "matches" && !isCall) {
+			return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %s", t)}
+		}
+		if isCall {
+			p.next()
+			args, end, err := p.parseList(")")
+			if err != nil {
+				return nil, err
+			}
+			return &callNode{position: position{t.pos, end}, name: t.text, args: args}, nil
+		}
+		return &identNode{position: position{t.pos, t.end}, name: t.text}, nil
+
+	case tokenOperator:
+		switch t.text {
+		case "(":
+			n, err := p.parseExpression(1)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case "[":
+			elements, end, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			return &arrayNode{position: position{t.pos, end}, elements: elements}, nil
+		}
+	}
+
+	return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %s", t)}
+}
+
+// parseList parses a comma separated list of expressions up to and including the closing token.
+// It returns the end position of the closing token.
+func (p *parser) parseList(closing string) ([]node, int, error) {
+	nodes := make([]node, 0)
+	if t := p.peek(); t.kind == tokenOperator && t.text == closing {
+		p.next()
+		return nodes, t.end, nil
+	}
+	for {
+		n, err := p.parseExpression(1)
+		if err != nil {
+			return nil, 0, err
+		}
+		nodes = append(nodes, n)
+
+		t := p.next()
+		if t.kind == tokenOperator && t.text == closing {
+			return nodes, t.end, nil
+		}
+		if t.kind != tokenOperator || t.text != "," {
+			return nil, 0, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("expected `,` or `%s`, got %s", closing, t)}
+		}
+	}
+}
@@ -3,6 +3,10 @@ module github.com/tomwright/apitestr
 go 1.13
 
 require (
+	github.com/PuerkitoBio/goquery v1.6.1
+	github.com/andybalholm/cascadia v1.1.0
+	github.com/antchfx/xmlquery v1.3.5
+	github.com/antchfx/xpath v1.2.4
 	github.com/stretchr/testify v1.5.1 // indirect
 	github.com/tidwall/gjson v1.6.0
 )
@@ -0,0 +1,188 @@
+package apitestr
+
+import (
+	"fmt"
+	"github.com/tidwall/gjson"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// DefaultMaxPages is the maximum number of pages requested by a paginated test if no limit is given
+	DefaultMaxPages = 10
+)
+
+// Pagination defines how a test follows the pages of a paginated response.
+// The test's captures and checks are run against every page.
+type Pagination struct {
+	// NextQuery is a gjson query to the next page URL or cursor in the response body.
+	// If empty, the `next` URL in the Link header is used.
+	NextQuery string
+	// CursorParam is the query parameter used to send the value found at NextQuery.
+	// If empty, the value found at NextQuery is used as the next page URL.
+	CursorParam string
+	// MaxPages is the maximum number of pages to request, including the first. Defaults to DefaultMaxPages.
+	MaxPages int
+	// ItemsQuery is a gjson query to the array of items on each page, used by the page-level assertions.
+	ItemsQuery string
+	// TotalItems is the expected number of items across all pages, if not nil.
+	TotalItems *int
+	// UniqueQuery is a gjson query, relative to each item, to a value that must not be repeated across pages.
+	UniqueQuery string
+}
+
+// PageFailedError is returned when a page of a paginated test fails.
+type PageFailedError struct {
+	// Page is the page number, starting at 1.
+	Page int
+	// URL is the URL of the page.
+	URL string
+	// Err is the error returned by the page.
+	Err error
+}
+
+// Error returns an error string.
+func (e *PageFailedError) Error() string {
+	return fmt.Sprintf("page %d (%s) failed: %s", e.Page, e.URL, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PageFailedError) Unwrap() error {
+	return e.Err
+}
+
+// UnexpectedItemCountError is returned when the number of items across all pages is not as expected.
+type UnexpectedItemCountError struct {
+	// Pages is the number of pages requested.
+	Pages int
+	// Expected is the expected number of items.
+	Expected int
+	// Actual is the actual number of items.
+	Actual int
+}
+
+// Error returns an error string.
+func (e *UnexpectedItemCountError) Error() string {
+	return fmt.Sprintf("unexpected item count across %d page(s): expected %d, got %d", e.Pages, e.Expected, e.Actual)
+}
+
+// DuplicateItemError is returned when an item is found more than once across pages.
+type DuplicateItemError struct {
+	// Query is the query used to identify items.
+	Query string
+	// Value is the duplicated value.
+	Value string
+	// FirstPage is the page the value was first found on.
+	FirstPage int
+	// Page is the page the value was found on again.
+	Page int
+}
+
+// Error returns an error string.
+func (e *DuplicateItemError) Error() string {
+	return fmt.Sprintf("duplicate item %v %v on page %d, first seen on page %d", e.Query, e.Value, e.Page, e.FirstPage)
+}
+
+// pageItems collects the items found on each page and validates them.
+type pageItems struct {
+	pagination *Pagination
+	count      int
+	seen       map[string]int
+}
+
+// add adds the items in the given page body.
+func (p *pageItems) add(page int, pageURL string, body []byte) error {
+	if p.pagination.ItemsQuery == "" {
+		return nil
+	}
+	items := gjson.GetBytes(body, p.pagination.ItemsQuery)
+	if !items.IsArray() {
+		return &PageFailedError{
+			Page: page,
+			URL:  pageURL,
+			Err:  fmt.Errorf("expected an array of items at %s", p.pagination.ItemsQuery),
+		}
+	}
+	for i, item := range items.Array() {
+		p.count++
+		if p.pagination.UniqueQuery == "" {
+			continue
+		}
+		value := item.Get(p.pagination.UniqueQuery)
+		if !value.Exists() {
+			return &PageFailedError{
+				Page: page,
+				URL:  pageURL,
+				Err:  fmt.Errorf("item [%d] has no value at %s", i, p.pagination.UniqueQuery),
+			}
+		}
+		if firstPage, ok := p.seen[value.Raw]; ok {
+			return &DuplicateItemError{
+				Query:     p.pagination.UniqueQuery,
+				Value:     value.Raw,
+				FirstPage: firstPage,
+				Page:      page,
+			}
+		}
+		p.seen[value.Raw] = page
+	}
+	return nil
+}
+
+// nextPageURL returns the URL of the page after the given response, or nil if there is no next page.
+func (p *Pagination) nextPageURL(response *http.Response, body []byte) (*url.URL, error) {
+	current := response.Request.URL
+
+	var next string
+	if p.NextQuery == "" {
+		next = linkHeaderURL(response.Header, "next")
+	} else {
+		r := gjson.GetBytes(body, p.NextQuery)
+		if r.Type != gjson.Null {
+			next = r.String()
+		}
+	}
+	if next == "" {
+		return nil, nil
+	}
+
+	if p.CursorParam != "" {
+		nextURL := *current
+		query := nextURL.Query()
+		query.Set(p.CursorParam, next)
+		nextURL.RawQuery = query.Encode()
+		return &nextURL, nil
+	}
+
+	nextURL, err := url.Parse(next)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse next page url: %w", err)
+	}
+	return current.ResolveReference(nextURL), nil
+}
+
+// linkHeaderURL returns the URL with the given relation type in the Link header, as defined by RFC 8288.
+func linkHeaderURL(header http.Header, rel string) string {
+	for _, headerVal := range header[http.CanonicalHeaderKey("Link")] {
+		for _, link := range strings.Split(headerVal, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				param = strings.TrimSpace(param)
+				if !strings.HasPrefix(strings.ToLower(param), "rel=") {
+					continue
+				}
+				for _, r := range strings.Fields(strings.Trim(param[4:], `"`)) {
+					if strings.EqualFold(r, rel) {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
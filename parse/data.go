@@ -60,3 +60,88 @@ func (d data) int(key string) (int, bool) {
 
 	return 0, false
 }
+
+func (d data) float(key string) (float64, bool) {
+	val, ok := d.get(key)
+	if !ok {
+		return 0, false
+	}
+
+	switch i := val.(type) {
+	case int:
+		return float64(i), true
+	case int32:
+		return float64(i), true
+	case int64:
+		return float64(i), true
+	case float32:
+		return float64(i), true
+	case float64:
+		return i, true
+	}
+
+	return 0, false
+}
+
+func (d data) strings(key string) ([]string, bool) {
+	val, ok := d.get(key)
+	if !ok {
+		return nil, false
+	}
+
+	switch i := val.(type) {
+	case string:
+		return []string{i}, true
+	case []string:
+		return i, true
+	case []interface{}:
+		res := make([]string, len(i))
+		for k, v := range i {
+			str, ok := v.(string)
+			if !ok {
+				return nil, false
+			}
+			res[k] = str
+		}
+		return res, true
+	}
+
+	return nil, false
+}
+
+func (d data) stringMap(key string) (map[string]string, bool) {
+	val, ok := d.get(key)
+	if !ok {
+		return nil, false
+	}
+
+	switch i := val.(type) {
+	case map[string]string:
+		return i, true
+	case map[string]interface{}:
+		res := make(map[string]string, len(i))
+		for k, v := range i {
+			str, ok := v.(string)
+			if !ok {
+				return nil, false
+			}
+			res[k] = str
+		}
+		return res, true
+	}
+
+	return nil, false
+}
+
+func (d data) bool(key string) (bool, bool) {
+	val, ok := d.get(key)
+	if !ok {
+		return false, false
+	}
+
+	if b, ok := val.(bool); ok {
+		return b, true
+	}
+
+	return false, false
+}
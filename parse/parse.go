@@ -17,7 +17,7 @@ func File(ctx context.Context, path string) (*apitestr.Test, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not read test file: %w", err)
 	}
-	return Parse(ctx, data)
+	return Parse(apitestr.ContextWithTestFilePath(ctx, path), data)
 }
 
 func Parse(ctx context.Context, data []byte) (*apitestr.Test, error) {
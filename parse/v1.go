@@ -7,18 +7,32 @@ import (
 	"fmt"
 	"github.com/tomwright/apitestr"
 	"github.com/tomwright/apitestr/check"
+	"github.com/tomwright/apitestr/expr"
 	"net/http"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+)
+
+type ctxKey string
+
+const (
+	ctxCheckPathKey ctxKey = "checkPath"
 )
 
 type v1 struct {
-	Name    string    `json:"name"`
-	Group   string    `json:"group"`
-	Order   int       `json:"order"`
-	Request v1Request `json:"request"`
-	Checks  []v1Check `json:"checks"`
+	Name              string           `json:"name"`
+	Group             string           `json:"group"`
+	Order             int              `json:"order"`
+	Request           v1Request        `json:"request"`
+	Capture           map[string]*data `json:"capture"`
+	Checks            []v1Check        `json:"checks"`
+	ReportAllFailures *bool            `json:"reportAllFailures"`
+	Paginate          *data            `json:"paginate"`
+	Poll              *data            `json:"poll"`
 }
 
 type v1Request struct {
@@ -100,6 +114,7 @@ func V1(ctx context.Context, data []byte) (*apitestr.Test, error) {
 		Checks:               make([]check.Checker, len(v.Checks)),
 		RequestInitFuncs:     requestInitFuncs,
 		RequestInitFuncsData: requestInitFuncsData,
+		ReportAllFailures:    v.ReportAllFailures,
 	}
 
 	if t.Name == "" {
@@ -112,8 +127,27 @@ func V1(ctx context.Context, data []byte) (*apitestr.Test, error) {
 		t.Order = 0
 	}
 
+	t.Captures, err = v1Captures(v.Capture)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Paginate != nil {
+		t.Paginate, err = v1Pagination(v.Paginate)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse paginate: %w", err)
+		}
+	}
+
+	if v.Poll != nil {
+		t.Poll, err = v1Poll(v.Poll)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse poll: %w", err)
+		}
+	}
+
 	for cIndex, c := range v.Checks {
-		checker, err := V1Check(ctx, c)
+		checker, err := V1Check(contextWithCheckIndex(ctx, cIndex), c)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse v1 check [%d]: %w", cIndex, err)
 		}
@@ -131,7 +165,42 @@ func V1Check(ctx context.Context, c v1Check) (check.Checker, error) {
 		if !ok {
 			return nil, fmt.Errorf("missing required data `value`")
 		}
-		return &check.BodyEqualChecker{Value: value}, nil
+		resolveData, _ := c.Data.bool("resolveData")
+		return &check.BodyEqualChecker{Value: value, ResolveData: resolveData}, nil
+
+	case "bodyContains":
+		value, ok := c.Data.string("value")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `value`")
+		}
+		resolveData, _ := c.Data.bool("resolveData")
+		return &check.BodyContainsChecker{Value: value, ResolveData: resolveData}, nil
+
+	case "bodyNotContains":
+		value, ok := c.Data.string("value")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `value`")
+		}
+		if value == "" {
+			return nil, fmt.Errorf("`value` data must not be empty")
+		}
+		resolveData, _ := c.Data.bool("resolveData")
+		return &check.BodyNotContainsChecker{Value: value, ResolveData: resolveData}, nil
+
+	case "bodyRegexMatch":
+		pattern, ok := c.Data.string("pattern")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `pattern`")
+		}
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("could not compile regex pattern `%s`: %w", pattern, err)
+		}
+		dataIDs, err := v1DataIDs(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &check.BodyRegexMatchChecker{Regexp: r, DataIDs: dataIDs}, nil
 
 	case "dataEqual":
 		id, ok := c.Data.string("id")
@@ -142,14 +211,16 @@ func V1Check(ctx context.Context, c v1Check) (check.Checker, error) {
 		if !ok {
 			return nil, fmt.Errorf("missing required data `value`")
 		}
-		return &check.DataEqualChecker{Value: value, DataID: id}, nil
+		resolveData, _ := c.Data.bool("resolveData")
+		return &check.DataEqualChecker{Value: value, ResolveData: resolveData, DataID: id}, nil
 
 	case "jsonBodyEqual":
 		value, ok := c.Data.get("value")
 		if !ok {
 			return nil, fmt.Errorf("missing required data `value`")
 		}
-		return &check.BodyJSONChecker{Value: value}, nil
+		resolveData, _ := c.Data.bool("resolveData")
+		return &check.BodyJSONChecker{Value: value, ResolveData: resolveData}, nil
 
 	case "jsonBodyQueryExists":
 		query, ok := c.Data.string("query")
@@ -159,6 +230,13 @@ func V1Check(ctx context.Context, c v1Check) (check.Checker, error) {
 		dataID, _ := c.Data.string("dataId")
 		return &check.BodyJSONQueryExistsChecker{Query: query, DataID: dataID}, nil
 
+	case "jsonBodyQueryNotExists":
+		query, ok := c.Data.string("query")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `query`")
+		}
+		return &check.BodyJSONQueryNotExistsChecker{Query: query}, nil
+
 	case "jsonBodyQueryEqual":
 		query, ok := c.Data.string("query")
 		if !ok {
@@ -169,7 +247,8 @@ func V1Check(ctx context.Context, c v1Check) (check.Checker, error) {
 			return nil, fmt.Errorf("missing required data `value`")
 		}
 		dataID, _ := c.Data.string("dataId")
-		return &check.BodyJSONQueryEqualChecker{Query: query, Value: value, NullValue: value == nil, DataID: dataID}, nil
+		resolveData, _ := c.Data.bool("resolveData")
+		return &check.BodyJSONQueryEqualChecker{Query: query, Value: value, NullValue: value == nil, ResolveData: resolveData, DataID: dataID}, nil
 
 	case "jsonBodyQueryRegexMatch":
 		query, ok := c.Data.string("query")
@@ -185,47 +264,520 @@ func V1Check(ctx context.Context, c v1Check) (check.Checker, error) {
 			return nil, fmt.Errorf("could not compile regex pattern `%s`: %w", pattern, err)
 		}
 
-		var dataIDs map[int]string
-		if dataIDsInterface, ok := c.Data.get("dataIds"); ok {
-			switch dataIDsOfType := dataIDsInterface.(type) {
-			case map[int]string:
-				dataIDs = dataIDsOfType
-			case map[string]string:
-				dataIDs = make(map[int]string, len(dataIDsOfType))
-				for k, v := range dataIDsOfType {
-					intK, err := strconv.Atoi(k)
-					if err != nil {
-						return nil, fmt.Errorf("could not parse `dataIds` key `%v` to int: %w", k, err)
-					}
-					dataIDs[intK] = v
+		dataIDs, err := v1DataIDs(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &check.BodyJSONQueryRegexMatchChecker{Query: query, Regexp: r, DataIDs: dataIDs}, nil
+
+	case "jsonBodyQueryCompare":
+		query, ok := c.Data.string("query")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `query`")
+		}
+		operator, ok := c.Data.string("operator")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `operator`")
+		}
+		mode, _ := c.Data.string("mode")
+		switch check.CompareMode(mode) {
+		case "", check.CompareModeNumber, check.CompareModeString, check.CompareModeSemver:
+		default:
+			return nil, fmt.Errorf("unhandled compare mode `%s`", mode)
+		}
+		dataID, _ := c.Data.string("dataId")
+		checker := &check.BodyJSONQueryCompareChecker{
+			Query:    query,
+			Operator: check.CompareOperator(operator),
+			Mode:     check.CompareMode(mode),
+			DataID:   dataID,
+		}
+		checker.ResolveData, _ = c.Data.bool("resolveData")
+		switch checker.Operator {
+		case check.CompareBetween:
+			if checker.Min, ok = c.Data.get("min"); !ok {
+				return nil, fmt.Errorf("missing required data `min`")
+			}
+			if checker.Max, ok = c.Data.get("max"); !ok {
+				return nil, fmt.Errorf("missing required data `max`")
+			}
+		case check.CompareApprox:
+			if checker.Tolerance, ok = c.Data.float("tolerance"); !ok {
+				return nil, fmt.Errorf("missing required data `tolerance`")
+			}
+			fallthrough
+		case check.CompareGreaterThan, check.CompareGreaterThanOrEqual, check.CompareLessThan, check.CompareLessThanOrEqual:
+			if checker.Value, ok = c.Data.get("value"); !ok {
+				return nil, fmt.Errorf("missing required data `value`")
+			}
+		default:
+			return nil, fmt.Errorf("unhandled compare operator `%s`", operator)
+		}
+		return checker, nil
+
+	case "jsonBodyQueryTime":
+		query, ok := c.Data.string("query")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `query`")
+		}
+		operator, ok := c.Data.string("operator")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `operator`")
+		}
+		format, _ := c.Data.string("format")
+		referenceDataID, _ := c.Data.string("referenceDataId")
+		dataID, _ := c.Data.string("dataId")
+		checker := &check.BodyJSONQueryTimeChecker{
+			Query:           query,
+			Format:          format,
+			Operator:        check.TimeOperator(operator),
+			ReferenceDataID: referenceDataID,
+			DataID:          dataID,
+		}
+		switch checker.Operator {
+		case check.TimeBefore, check.TimeAfter:
+		case check.TimeWithin:
+			if _, ok := c.Data.string("within"); !ok {
+				return nil, fmt.Errorf("missing required data `within`")
+			}
+			within, err := v1Duration(c.Data, "within")
+			if err != nil {
+				return nil, err
+			}
+			checker.Within = within
+		default:
+			return nil, fmt.Errorf("unhandled time operator `%s`", operator)
+		}
+		return checker, nil
+
+	case "jsonBodyQueryLength":
+		query, ok := c.Data.string("query")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `query`")
+		}
+		value, min, max, err := v1IntBounds(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &check.BodyJSONQueryLengthChecker{Query: query, Value: value, Min: min, Max: max}, nil
+
+	case "jsonBodyQueryUnique":
+		query, ok := c.Data.string("query")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `query`")
+		}
+		path, _ := c.Data.string("path")
+		return &check.BodyJSONQueryUniqueChecker{Query: query, Path: path}, nil
+
+	case "jsonBodyQuerySorted":
+		query, ok := c.Data.string("query")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `query`")
+		}
+		by, _ := c.Data.string("by")
+		direction, _ := c.Data.string("direction")
+		switch check.SortDirection(direction) {
+		case "", check.SortAscending, check.SortDescending:
+		default:
+			return nil, fmt.Errorf("unhandled sort direction `%s`", direction)
+		}
+		return &check.BodyJSONQuerySortedChecker{Query: query, By: by, Direction: check.SortDirection(direction)}, nil
+
+	case "jsonBodyQueryContains":
+		query, ok := c.Data.string("query")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `query`")
+		}
+		value, ok := c.Data.get("value")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `value`")
+		}
+		dataID, _ := c.Data.string("dataId")
+		resolveData, _ := c.Data.bool("resolveData")
+		return &check.BodyJSONQueryContainsChecker{Query: query, Value: value, ResolveData: resolveData, DataID: dataID}, nil
+
+	case "jsonBodyQueryType":
+		query, ok := c.Data.string("query")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `query`")
+		}
+		types, ok := c.Data.strings("type")
+		if _, exists := c.Data.get("type"); exists && !ok {
+			return nil, fmt.Errorf("`type` data must be a string or an array of strings")
+		}
+		for _, t := range types {
+			switch t {
+			case check.JSONTypeString, check.JSONTypeNumber, check.JSONTypeInteger, check.JSONTypeBoolean,
+				check.JSONTypeObject, check.JSONTypeArray, check.JSONTypeNull:
+			default:
+				return nil, fmt.Errorf("unhandled json type `%s`", t)
+			}
+		}
+		format, _ := c.Data.string("format")
+		if format != "" && !check.ValidJSONFormat(format) {
+			return nil, fmt.Errorf("unhandled format `%s`", format)
+		}
+		if format != "" && len(types) > 0 && (len(types) != 1 || types[0] != check.JSONTypeString) {
+			return nil, fmt.Errorf("`format` can only be used with `type` of `string`")
+		}
+		if len(types) == 0 && format == "" {
+			return nil, fmt.Errorf("missing required data: one of `type` or `format`")
+		}
+		dataID, _ := c.Data.string("dataId")
+		return &check.BodyJSONQueryTypeChecker{Query: query, Types: types, Format: format, DataID: dataID}, nil
+
+	case "xmlBodyQueryExists":
+		query, namespaces, err := v1XMLQuery(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		dataID, _ := c.Data.string("dataId")
+		return &check.BodyXMLQueryExistsChecker{Query: query, Namespaces: namespaces, DataID: dataID}, nil
+
+	case "xmlBodyQueryEqual":
+		query, namespaces, err := v1XMLQuery(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := c.Data.get("value")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `value`")
+		}
+		var valueStr string
+		switch valueOfType := value.(type) {
+		case string:
+			valueStr = valueOfType
+		case float64:
+			valueStr = strconv.FormatFloat(valueOfType, 'f', -1, 64)
+		case bool:
+			valueStr = strconv.FormatBool(valueOfType)
+		default:
+			return nil, fmt.Errorf("could not parse `value` data. expected string, number or bool, got %T", value)
+		}
+		dataID, _ := c.Data.string("dataId")
+		resolveData, _ := c.Data.bool("resolveData")
+		return &check.BodyXMLQueryEqualChecker{Query: query, Namespaces: namespaces, Value: valueStr, ResolveData: resolveData, DataID: dataID}, nil
+
+	case "xmlBodyQueryRegexMatch":
+		query, namespaces, err := v1XMLQuery(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		pattern, ok := c.Data.string("pattern")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `pattern`")
+		}
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("could not compile regex pattern `%s`: %w", pattern, err)
+		}
+		dataIDs, err := v1DataIDs(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &check.BodyXMLQueryRegexMatchChecker{Query: query, Namespaces: namespaces, Regexp: r, DataIDs: dataIDs}, nil
+
+	case "htmlBodySelectorExists":
+		selector, err := v1HTMLSelector(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		attribute, _ := c.Data.string("attribute")
+		dataID, _ := c.Data.string("dataId")
+		return &check.BodyHTMLSelectorExistsChecker{Selector: selector, Attribute: attribute, DataID: dataID}, nil
+
+	case "htmlBodySelectorCount":
+		selector, err := v1HTMLSelector(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		value, min, max, err := v1IntBounds(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &check.BodyHTMLSelectorCountChecker{Selector: selector, Value: value, Min: min, Max: max}, nil
+
+	case "htmlBodySelectorEqual":
+		selector, err := v1HTMLSelector(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := c.Data.string("value")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `value`")
+		}
+		attribute, _ := c.Data.string("attribute")
+		dataID, _ := c.Data.string("dataId")
+		resolveData, _ := c.Data.bool("resolveData")
+		return &check.BodyHTMLSelectorEqualChecker{Selector: selector, Attribute: attribute, Value: value, ResolveData: resolveData, DataID: dataID}, nil
+
+	case "htmlBodySelectorRegexMatch":
+		selector, err := v1HTMLSelector(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		pattern, ok := c.Data.string("pattern")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `pattern`")
+		}
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("could not compile regex pattern `%s`: %w", pattern, err)
+		}
+		dataIDs, err := v1DataIDs(c.Data)
+		if err != nil {
+			return nil, err
+		}
+		attribute, _ := c.Data.string("attribute")
+		return &check.BodyHTMLSelectorRegexMatchChecker{Selector: selector, Attribute: attribute, Regexp: r, DataIDs: dataIDs}, nil
+
+	case "snapshot":
+		file, _ := c.Data.string("file")
+		testFilePath := apitestr.TestFilePathFromContext(ctx)
+		switch {
+		case file == "" && testFilePath == "":
+			return nil, fmt.Errorf("missing required data `file`")
+		case file == "":
+			// include the index of the check so that each snapshot check in the test uses a different file
+			name := strings.TrimSuffix(filepath.Base(testFilePath), filepath.Ext(testFilePath))
+			if checkPath := checkPathFromContext(ctx); checkPath != "" {
+				name += "." + checkPath
+			}
+			file = filepath.Join(filepath.Dir(testFilePath), "_"+name+".snapshot.json")
+		default:
+			file = resolveTestFilePath(ctx, file)
+		}
+		status, _ := c.Data.bool("status")
+		headers, _ := c.Data.strings("headers")
+		ignore, _ := c.Data.strings("ignore")
+		return &check.SnapshotChecker{File: file, Status: status, Headers: headers, Ignore: ignore}, nil
+
+	case "headerEqual":
+		name, ok := c.Data.string("name")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `name`")
+		}
+		value, ok := c.Data.string("value")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `value`")
+		}
+		dataID, _ := c.Data.string("dataId")
+		resolveData, _ := c.Data.bool("resolveData")
+		return &check.HeaderEqualChecker{Name: name, Value: value, ResolveData: resolveData, DataID: dataID}, nil
+
+	case "cookie":
+		name, ok := c.Data.string("name")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `name`")
+		}
+		checker := &check.CookieChecker{Name: name}
+		if pattern, ok := c.Data.string("pattern"); ok {
+			r, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("could not compile regex pattern `%s`: %w", pattern, err)
+			}
+			checker.Value = r
+		}
+		if httpOnly, ok := c.Data.bool("httpOnly"); ok {
+			checker.HTTPOnly = &httpOnly
+		}
+		if secure, ok := c.Data.bool("secure"); ok {
+			checker.Secure = &secure
+		}
+		if session, ok := c.Data.bool("session"); ok {
+			checker.Session = &session
+		}
+		checker.SameSite, _ = c.Data.string("sameSite")
+		switch checker.SameSite {
+		case "", check.CookieSameSiteDefault, check.CookieSameSiteLax, check.CookieSameSiteStrict, check.CookieSameSiteNone:
+		default:
+			return nil, fmt.Errorf("unhandled sameSite value `%s`", checker.SameSite)
+		}
+		checker.Path, _ = c.Data.string("path")
+		var err error
+		if checker.MinLifetime, err = v1Duration(c.Data, "minLifetime"); err != nil {
+			return nil, err
+		}
+		if checker.MaxLifetime, err = v1Duration(c.Data, "maxLifetime"); err != nil {
+			return nil, err
+		}
+		checker.DataID, _ = c.Data.string("dataId")
+		return checker, nil
+
+	case "jwt":
+		query, _ := c.Data.string("query")
+		header, _ := c.Data.string("header")
+		if (query == "") == (header == "") {
+			return nil, fmt.Errorf("exactly one of `query` or `header` data is required")
+		}
+		secret, _ := c.Data.string("secret")
+		issuer, _ := c.Data.string("issuer")
+		audience, _ := c.Data.string("audience")
+		notExpired, _ := c.Data.bool("notExpired")
+		leeway, err := v1Duration(c.Data, "leeway")
+		if err != nil {
+			return nil, err
+		}
+		claimDataIDs, ok := c.Data.stringMap("capture")
+		if _, exists := c.Data.get("capture"); exists && !ok {
+			return nil, fmt.Errorf("`capture` data must be an object of claim names to data ids")
+		}
+		dataID, _ := c.Data.string("dataId")
+		checker := &check.JWTChecker{
+			Query:        query,
+			Header:       header,
+			Secret:       secret,
+			Issuer:       issuer,
+			Audience:     audience,
+			NotExpired:   notExpired,
+			Leeway:       leeway,
+			ClaimDataIDs: claimDataIDs,
+			DataID:       dataID,
+		}
+		checker.ResolveData, _ = c.Data.bool("resolveData")
+		if claims, ok := c.Data.get("claims"); ok {
+			if checker.Claims, ok = claims.(map[string]interface{}); !ok {
+				return nil, fmt.Errorf("`claims` data must be an object")
+			}
+		}
+		if keyFile, ok := c.Data.string("keyFile"); ok {
+			if checker.Keys, err = check.ReadJWTKeyFile(resolveTestFilePath(ctx, keyFile)); err != nil {
+				return nil, fmt.Errorf("could not load `keyFile` `%s`: %w", keyFile, err)
+			}
+		}
+		return checker, nil
+
+	case "problemDetails":
+		problemType, _ := c.Data.string("type")
+		checker := &check.ProblemDetailsChecker{Type: problemType}
+		checker.ResolveData, _ = c.Data.bool("resolveData")
+		if extensions, ok := c.Data.get("extensions"); ok {
+			if checker.Extensions, ok = extensions.(map[string]interface{}); !ok {
+				return nil, fmt.Errorf("`extensions` data must be an object")
+			}
+		}
+		return checker, nil
+
+	case "securityHeaders":
+		hstsMinMaxAge, err := v1Duration(c.Data, "hstsMinMaxAge")
+		if err != nil {
+			return nil, err
+		}
+		hstsIncludeSubDomains, _ := c.Data.bool("hstsIncludeSubDomains")
+		forbidden, _ := c.Data.strings("forbidden")
+		checker := &check.SecurityHeadersChecker{
+			HSTSMinMaxAge:         hstsMinMaxAge,
+			HSTSIncludeSubDomains: hstsIncludeSubDomains,
+			Forbidden:             forbidden,
+		}
+		// each part of the default policy is enabled unless explicitly set to false
+		for key, ignore := range map[string]*bool{
+			"hsts":               &checker.IgnoreHSTS,
+			"contentTypeOptions": &checker.IgnoreContentTypeOptions,
+			"frameProtection":    &checker.IgnoreFrameProtection,
+			"csp":                &checker.IgnoreCSP,
+			"serverVersion":      &checker.IgnoreServerVersion,
+		} {
+			if enabled, ok := c.Data.bool(key); ok {
+				*ignore = !enabled
+			}
+		}
+		if _, ok := c.Data.get("required"); ok {
+			required, ok := c.Data.stringMap("required")
+			if !ok {
+				return nil, fmt.Errorf("`required` data must be an object of header names to patterns")
+			}
+			checker.Required = make(map[string]*regexp.Regexp, len(required))
+			for name, pattern := range required {
+				if pattern == "" {
+					checker.Required[name] = nil
+					continue
 				}
-			case map[string]interface{}:
-				dataIDs = make(map[int]string, len(dataIDsOfType))
-				for k, interfaceVal := range dataIDsOfType {
-					intK, err := strconv.Atoi(k)
-					if err != nil {
-						return nil, fmt.Errorf("could not parse `dataIds` key `%v` to int: %w", k, err)
-					}
-
-					switch valOfType := interfaceVal.(type) {
-					case string:
-						dataIDs[intK] = valOfType
-					case []byte:
-						dataIDs[intK] = string(valOfType)
-					default:
-						return nil, fmt.Errorf("could not parse `dataIds` value for `%d` to string", intK)
-					}
+				r, err := regexp.Compile(pattern)
+				if err != nil {
+					return nil, fmt.Errorf("could not compile regex pattern `%s`: %w", pattern, err)
 				}
-			default:
-				return nil, fmt.Errorf("could not parse `dataIds` data. expected type of `map[int]string` or `map[string]string`, got %T", dataIDsInterface)
+				checker.Required[name] = r
 			}
-		} else {
-			dataIDs = make(map[int]string)
 		}
-		if dataID, _ := c.Data.string("dataId"); dataID != "" {
-			dataIDs[0] = dataID
+		return checker, nil
+
+	case "contentEncoding":
+		encoding, _ := c.Data.string("encoding")
+		maxCompressedSize, _ := c.Data.int("maxCompressedSize")
+		minRatio, _ := c.Data.float("minRatio")
+		return &check.ContentEncodingChecker{
+			Encoding:          strings.ToLower(encoding),
+			MaxCompressedSize: maxCompressedSize,
+			MinRatio:          minRatio,
+		}, nil
+
+	case "cors":
+		origin, ok := c.Data.string("origin")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `origin`")
 		}
-		return &check.BodyJSONQueryRegexMatchChecker{Query: query, Regexp: r, DataIDs: dataIDs}, nil
+		method, _ := c.Data.string("method")
+		headers, _ := c.Data.strings("headers")
+		minMaxAge, err := v1Duration(c.Data, "minMaxAge")
+		if err != nil {
+			return nil, err
+		}
+		denied, _ := c.Data.bool("denied")
+		checker := &check.CORSChecker{
+			Origin:    origin,
+			Method:    strings.ToUpper(method),
+			Headers:   headers,
+			MinMaxAge: minMaxAge,
+			Denied:    denied,
+		}
+		if credentials, ok := c.Data.bool("credentials"); ok {
+			checker.Credentials = &credentials
+		}
+		return checker, nil
+
+	case "anyOf":
+		checks, err := v1NestedChecks(ctx, c.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &check.AnyOfChecker{Checks: checks}, nil
+
+	case "allOf":
+		checks, err := v1NestedChecks(ctx, c.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &check.AllOfChecker{Checks: checks}, nil
+
+	case "not":
+		checks, err := v1NestedChecks(ctx, c.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &check.NotChecker{Checks: checks}, nil
+
+	case "forEach":
+		query, ok := c.Data.string("query")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `query`")
+		}
+		checks, err := v1NestedChecks(ctx, c.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &check.BodyJSONQueryForEachChecker{Query: query, Checks: checks}, nil
+
+	case "expr":
+		expression, ok := c.Data.string("expression")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `expression`")
+		}
+		program, err := expr.Compile(expression)
+		if err != nil {
+			return nil, fmt.Errorf("could not compile expression `%s`: %w", expression, err)
+		}
+		return &check.ExprChecker{Program: program}, nil
 
 	case "statusCodeEqual":
 		value, ok := c.Data.int("value")
@@ -249,3 +801,282 @@ func V1Check(ctx context.Context, c v1Check) (check.Checker, error) {
 		return nil, fmt.Errorf("unhandled type `%s`", c.Type)
 	}
 }
+
+// v1Captures parses the `capture` section of a test, sorted by data ID.
+func v1Captures(captureData map[string]*data) ([]check.Capture, error) {
+	dataIDs := make([]string, 0, len(captureData))
+	for dataID := range captureData {
+		dataIDs = append(dataIDs, dataID)
+	}
+	sort.Strings(dataIDs)
+
+	captures := make([]check.Capture, len(dataIDs))
+	for i, dataID := range dataIDs {
+		capturer, err := v1Capturer(captureData[dataID])
+		if err != nil {
+			return nil, fmt.Errorf("could not parse capture `%s`: %w", dataID, err)
+		}
+		captures[i] = check.Capture{DataID: dataID, Capturer: capturer}
+	}
+	return captures, nil
+}
+
+func v1Pagination(d *data) (*apitestr.Pagination, error) {
+	nextQuery, _ := d.string("nextQuery")
+	cursorParam, _ := d.string("cursorParam")
+	if cursorParam != "" && nextQuery == "" {
+		return nil, fmt.Errorf("missing required data `nextQuery`")
+	}
+	maxPages, _ := d.int("maxPages")
+	itemsQuery, _ := d.string("itemsQuery")
+	uniqueQuery, _ := d.string("uniqueQuery")
+	pagination := &apitestr.Pagination{
+		NextQuery:   nextQuery,
+		CursorParam: cursorParam,
+		MaxPages:    maxPages,
+		ItemsQuery:  itemsQuery,
+		UniqueQuery: uniqueQuery,
+	}
+	if totalItems, ok := d.int("totalItems"); ok {
+		pagination.TotalItems = &totalItems
+	}
+	if itemsQuery == "" && (pagination.TotalItems != nil || uniqueQuery != "") {
+		return nil, fmt.Errorf("missing required data `itemsQuery`")
+	}
+	return pagination, nil
+}
+
+func v1Poll(d *data) (*apitestr.Poll, error) {
+	interval, err := v1Duration(d, "interval")
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := v1Duration(d, "timeout")
+	if err != nil {
+		return nil, err
+	}
+	maxAttempts, _ := d.int("maxAttempts")
+	backoff, ok := d.float("backoff")
+	if ok && backoff < 1 {
+		return nil, fmt.Errorf("`backoff` must be at least 1, got %v", backoff)
+	}
+	return &apitestr.Poll{
+		Interval:    interval,
+		Timeout:     timeout,
+		MaxAttempts: maxAttempts,
+		Backoff:     backoff,
+	}, nil
+}
+
+func v1Capturer(d *data) (check.Capturer, error) {
+	if d == nil {
+		return nil, fmt.Errorf("missing required data `from`")
+	}
+	from, ok := d.string("from")
+	if !ok {
+		return nil, fmt.Errorf("missing required data `from`")
+	}
+	switch from {
+	case "status":
+		return &check.StatusCodeCapturer{}, nil
+
+	case "header", "cookie":
+		name, ok := d.string("name")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `name`")
+		}
+		if from == "header" {
+			return &check.HeaderCapturer{Name: name}, nil
+		}
+		return &check.CookieCapturer{Name: name}, nil
+
+	case "json":
+		query, ok := d.string("query")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `query`")
+		}
+		return &check.BodyJSONQueryCapturer{Query: query}, nil
+
+	case "regex":
+		pattern, ok := d.string("pattern")
+		if !ok {
+			return nil, fmt.Errorf("missing required data `pattern`")
+		}
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("could not compile regex pattern `%s`: %w", pattern, err)
+		}
+		group, ok := d.int("group")
+		if !ok && r.NumSubexp() > 0 {
+			group = 1
+		}
+		if group < 0 || group > r.NumSubexp() {
+			return nil, fmt.Errorf("regex pattern `%s` does not have group %d", pattern, group)
+		}
+		return &check.BodyRegexCapturer{Regexp: r, Group: group}, nil
+
+	case "body":
+		return &check.BodyCapturer{}, nil
+	}
+	return nil, fmt.Errorf("unhandled capture source `%s`", from)
+}
+
+// v1DataIDs parses the optional `dataIds` and `dataId` data used to store regex matches.
+func v1DataIDs(d *data) (map[int]string, error) {
+	var dataIDs map[int]string
+	if dataIDsInterface, ok := d.get("dataIds"); ok {
+		switch dataIDsOfType := dataIDsInterface.(type) {
+		case map[int]string:
+			dataIDs = dataIDsOfType
+		case map[string]string:
+			dataIDs = make(map[int]string, len(dataIDsOfType))
+			for k, v := range dataIDsOfType {
+				intK, err := strconv.Atoi(k)
+				if err != nil {
+					return nil, fmt.Errorf("could not parse `dataIds` key `%v` to int: %w", k, err)
+				}
+				dataIDs[intK] = v
+			}
+		case map[string]interface{}:
+			dataIDs = make(map[int]string, len(dataIDsOfType))
+			for k, interfaceVal := range dataIDsOfType {
+				intK, err := strconv.Atoi(k)
+				if err != nil {
+					return nil, fmt.Errorf("could not parse `dataIds` key `%v` to int: %w", k, err)
+				}
+
+				switch valOfType := interfaceVal.(type) {
+				case string:
+					dataIDs[intK] = valOfType
+				case []byte:
+					dataIDs[intK] = string(valOfType)
+				default:
+					return nil, fmt.Errorf("could not parse `dataIds` value for `%d` to string", intK)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("could not parse `dataIds` data. expected type of `map[int]string` or `map[string]string`, got %T", dataIDsInterface)
+		}
+	} else {
+		dataIDs = make(map[int]string)
+	}
+	if dataID, _ := d.string("dataId"); dataID != "" {
+		dataIDs[0] = dataID
+	}
+	return dataIDs, nil
+}
+
+// v1XMLQuery parses and validates the `query` and optional `namespaces` data used by xml checks.
+func v1XMLQuery(d *data) (string, map[string]string, error) {
+	query, ok := d.string("query")
+	if !ok {
+		return "", nil, fmt.Errorf("missing required data `query`")
+	}
+	var namespaces map[string]string
+	if _, ok := d.get("namespaces"); ok {
+		if namespaces, ok = d.stringMap("namespaces"); !ok {
+			return "", nil, fmt.Errorf("could not parse `namespaces` data. expected map of prefix to namespace uri")
+		}
+	}
+	if _, err := check.CompileXMLQuery(query, namespaces); err != nil {
+		return "", nil, err
+	}
+	return query, namespaces, nil
+}
+
+// v1HTMLSelector parses and validates the `selector` data used by html checks.
+func v1HTMLSelector(d *data) (string, error) {
+	selector, ok := d.string("selector")
+	if !ok {
+		return "", fmt.Errorf("missing required data `selector`")
+	}
+	if _, err := check.CompileHTMLSelector(selector); err != nil {
+		return "", err
+	}
+	return selector, nil
+}
+
+// v1IntBounds parses the `value`, `min` and `max` data used by count checks. At least one must be given.
+func v1IntBounds(d *data) (*int, *int, *int, error) {
+	var value, min, max *int
+	if v, ok := d.int("value"); ok {
+		value = &v
+	}
+	if v, ok := d.int("min"); ok {
+		min = &v
+	}
+	if v, ok := d.int("max"); ok {
+		max = &v
+	}
+	if value == nil && min == nil && max == nil {
+		return nil, nil, nil, fmt.Errorf("missing required data: one of `value`, `min` or `max`")
+	}
+	return value, min, max, nil
+}
+
+// contextWithCheckIndex stores the index of the check being parsed in the context.
+// The indexes of nested checks are appended to the index of their parent check, e.g. `2.0`.
+func contextWithCheckIndex(ctx context.Context, index int) context.Context {
+	path := strconv.Itoa(index)
+	if parent := checkPathFromContext(ctx); parent != "" {
+		path = parent + "." + path
+	}
+	return context.WithValue(ctx, ctxCheckPathKey, path)
+}
+
+// checkPathFromContext returns the index of the check being parsed, as stored in the given context.
+func checkPathFromContext(ctx context.Context) string {
+	path, _ := ctx.Value(ctxCheckPathKey).(string)
+	return path
+}
+
+// resolveTestFilePath resolves the given relative path against the directory of the test file being parsed.
+func resolveTestFilePath(ctx context.Context, path string) string {
+	testFilePath := apitestr.TestFilePathFromContext(ctx)
+	if testFilePath == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(testFilePath), path)
+}
+
+// v1Duration parses the optional duration data with the given key, e.g. `1h30m`.
+func v1Duration(d *data, key string) (time.Duration, error) {
+	str, ok := d.string(key)
+	if !ok {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse `%s` duration `%s`: %w", key, str, err)
+	}
+	return duration, nil
+}
+
+// v1NestedChecks parses the required `checks` data used by checks that contain other checks.
+func v1NestedChecks(ctx context.Context, d *data) ([]check.Checker, error) {
+	checksData, ok := d.get("checks")
+	if !ok {
+		return nil, fmt.Errorf("missing required data `checks`")
+	}
+	// the nested checks have already been decoded, so re-encode them to parse them in the same way as top level checks
+	checksBytes, err := json.Marshal(checksData)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal `checks` data: %w", err)
+	}
+	var v1Checks []v1Check
+	if err := json.Unmarshal(checksBytes, &v1Checks); err != nil {
+		return nil, fmt.Errorf("could not parse `checks` data. expected array of checks: %w", err)
+	}
+	if len(v1Checks) == 0 {
+		return nil, fmt.Errorf("`checks` data must contain at least one check")
+	}
+	checks := make([]check.Checker, len(v1Checks))
+	for cIndex, c := range v1Checks {
+		checker, err := V1Check(contextWithCheckIndex(ctx, cIndex), c)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse nested check [%d]: %w", cIndex, err)
+		}
+		checks[cIndex] = checker
+	}
+	return checks, nil
+}
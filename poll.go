@@ -0,0 +1,137 @@
+package apitestr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultPollInterval is the time waited between poll attempts if no interval is given
+	DefaultPollInterval = time.Second
+	// DefaultPollTimeout is the poll timeout used if neither a timeout or max attempts are given
+	DefaultPollTimeout = 30 * time.Second
+)
+
+// Poll defines how a test is retried until all of its checks pass.
+type Poll struct {
+	// Interval is the time waited after the first failed attempt. Defaults to DefaultPollInterval.
+	Interval time.Duration
+	// Timeout is the maximum time spent polling. Zero means no timeout.
+	// If both Timeout and MaxAttempts are zero, DefaultPollTimeout is used.
+	Timeout time.Duration
+	// MaxAttempts is the maximum number of attempts. Zero means no limit.
+	MaxAttempts int
+	// Backoff is multiplied with the interval after each failed attempt. Defaults to 1, meaning a constant interval.
+	Backoff float64
+}
+
+// PollFailedError is returned when a polled test does not pass before the timeout or max attempts are reached.
+type PollFailedError struct {
+	// Attempts is the number of attempts made.
+	Attempts int
+	// Elapsed is the time spent polling.
+	Elapsed time.Duration
+	// Err is the error returned by the last attempt.
+	Err error
+}
+
+// Error returns an error string.
+func (e *PollFailedError) Error() string {
+	return fmt.Sprintf("failed after %d attempt(s) in %s: %s", e.Attempts, e.Elapsed.Round(time.Millisecond), e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PollFailedError) Unwrap() error {
+	return e.Err
+}
+
+// runPoll runs the test until it passes, or the poll timeout or max attempts are reached.
+// Each attempt re-sends the test request.
+func runPoll(ctx context.Context, t *Test, logger *log.Logger, run func() error) error {
+	interval := t.Poll.Interval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	backoff := t.Poll.Backoff
+	if backoff < 1 {
+		backoff = 1
+	}
+	timeout := t.Poll.Timeout
+	if timeout <= 0 && t.Poll.MaxAttempts <= 0 {
+		timeout = DefaultPollTimeout
+	}
+
+	req := t.Request
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		err := run()
+		if err == nil {
+			return nil
+		}
+
+		pollErr := &PollFailedError{
+			Attempts: attempt,
+			Elapsed:  time.Since(start),
+			Err:      err,
+		}
+
+		if t.Poll.MaxAttempts > 0 && attempt >= t.Poll.MaxAttempts {
+			return pollErr
+		}
+		wait := interval
+		if timeout > 0 {
+			remaining := timeout - pollErr.Elapsed
+			if remaining <= 0 {
+				return pollErr
+			}
+			if wait > remaining {
+				wait = remaining
+			}
+		}
+
+		if logger != nil {
+			logger.Printf("test `%s` attempt %d failed, retrying in %s: %s\n", t.Name, attempt, wait, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return pollErr
+		case <-time.After(wait):
+		}
+		interval = time.Duration(float64(interval) * backoff)
+
+		t.Request, err = copyRequest(ctx, req, req.URL.String())
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// copyRequest returns a copy of the given request for the given URL.
+func copyRequest(ctx context.Context, req *http.Request, url string) (*http.Request, error) {
+	var body io.ReadCloser
+	if req.GetBody != nil {
+		var err error
+		body, err = req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("could not copy request body: %w", err)
+		}
+	}
+	next, err := http.NewRequest(req.Method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("could not copy request: %w", err)
+	}
+	next.Header = req.Header.Clone()
+	next.ContentLength = req.ContentLength
+	next.GetBody = req.GetBody
+	// only keep an overridden Host if the copy is sent to the same server
+	if next.URL.Host == req.URL.Host {
+		next.Host = req.Host
+	}
+	return next.WithContext(ctx), nil
+}
@@ -101,6 +101,7 @@ func RequestBodyReplacements(ctx context.Context, req *http.Request, data map[st
 	return req, nil
 }
 
+// getReplacementValue returns the given replacement value as a string, resolving it if it is a data reference such as `$.userId`.
 func getReplacementValue(ctx context.Context, val interface{}) (string, error) {
 	var valStr string
 
@@ -115,18 +116,5 @@ func getReplacementValue(ctx context.Context, val interface{}) (string, error) {
 		return "", fmt.Errorf("unhandled replacement value type of `%T` with value `%v`", val, val)
 	}
 
-	if strings.HasPrefix(valStr, "$.") {
-		dataVal := check.DataIDFromContext(ctx, strings.TrimLeft(valStr, "$."))
-		switch valOfType := dataVal.(type) {
-		case string:
-			valStr = valOfType
-		case []byte:
-			valStr = string(valOfType)
-		case nil:
-			valStr = ""
-		default:
-			return "", fmt.Errorf("unhandled replacement variable value type of `%T` with value `%v`", val, val)
-		}
-	}
-	return valStr, nil
+	return check.ResolveDataReferenceString(ctx, valStr)
 }
@@ -92,6 +92,31 @@ func TestRequestReplacements(t *testing.T) {
 				return ctx
 			},
 		},
+		{
+			desc: "non-string variable replacements are encoded as json",
+			replacements: map[string]interface{}{
+				":id:": "$.id",
+			},
+			url:             "https://example.com/users/:id:",
+			body:            []byte("id=:id:"),
+			expectedUrl:     "https://example.com/users/123",
+			expectedBody:    []byte("id=123"),
+			expectedHeaders: map[string]string{},
+			ctx: func(ctx context.Context) context.Context {
+				return check.ContextWithData(ctx, map[string]interface{}{"id": float64(123)})
+			},
+		},
+		{
+			desc: "escaped variable replacements are literal",
+			replacements: map[string]interface{}{
+				":path:": "$$.user.id",
+			},
+			url:             "https://example.com/users",
+			body:            []byte("path=:path:"),
+			expectedUrl:     "https://example.com/users",
+			expectedBody:    []byte("path=$.user.id"),
+			expectedHeaders: map[string]string{},
+		},
 	}
 
 	for _, testCase := range tests {
@@ -133,3 +158,18 @@ func TestRequestReplacements(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestReplacements_MissingData(t *testing.T) {
+	t.Parallel()
+
+	ctx := check.ContextWithData(context.Background(), make(map[string]interface{}))
+	req, _ := http.NewRequest("GET", "https://example.com/users/:id:", nil)
+
+	_, err := apitestr.RequestReplacements(ctx, req, map[string]interface{}{":id:": "$.id"})
+	if err == nil {
+		t.Fatalf("expected error but got none")
+	}
+	if exp, got := "data id `id` has not been stored", err.Error(); exp != got {
+		t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+	}
+}
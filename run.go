@@ -1,19 +1,35 @@
 package apitestr
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"github.com/tomwright/apitestr/check"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptrace"
 	"sync"
+	"time"
 )
 
 const (
 	DefaultMaxConcurrentTests = 5
 )
 
+// CookieJarScope defines how cookies are shared between tests executed by RunAll
+type CookieJarScope string
+
+const (
+	// CookieJarNone uses the given HTTPClient as is
+	CookieJarNone CookieJarScope = ""
+	// CookieJarSuite shares a single cookie jar between all tests
+	CookieJarSuite CookieJarScope = "suite"
+	// CookieJarGroup uses a separate cookie jar for each group of tests
+	CookieJarGroup CookieJarScope = "group"
+)
+
 // Run executes a single test
 func Run(ctx context.Context, t *Test, httpClient *http.Client, logger *log.Logger) error {
 	testData := check.DataFromContext(ctx)
@@ -40,15 +56,158 @@ func Run(ctx context.Context, t *Test, httpClient *http.Client, logger *log.Logg
 		}
 	}
 
+	ctx = check.ContextWithHTTPClient(ctx, httpClient)
+
+	run := func() error {
+		body, err := runPage(ctx, t, httpClient)
+		if t.Paginate == nil {
+			return err
+		}
+		if err != nil {
+			return &PageFailedError{
+				Page: 1,
+				URL:  t.Request.URL.String(),
+				Err:  err,
+			}
+		}
+		return runPages(ctx, t, httpClient, logger, body)
+	}
+
+	if t.Poll != nil {
+		return runPoll(ctx, t, logger, run)
+	}
+	return run()
+}
+
+// runPage sends the test request, and runs the captures and checks against the response.
+// The response body is returned.
+func runPage(ctx context.Context, t *Test, httpClient *http.Client) ([]byte, error) {
+	var err error
+
+	timings := check.Timings{}
+	start := time.Now()
+	trace := &httptrace.ClientTrace{
+		GotFirstResponseByte: func() {
+			timings.FirstByte = time.Since(start)
+		},
+	}
+	t.Request = t.Request.WithContext(httptrace.WithClientTrace(t.Request.Context(), trace))
+
 	t.Response, err = httpClient.Do(t.Request)
 	if err != nil {
-		return fmt.Errorf("could not execute request: %w", err)
+		return nil, fmt.Errorf("could not execute request: %w", err)
+	}
+
+	body, err := ioutil.ReadAll(t.Response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response body: %w", err)
+	}
+	if err := t.Response.Body.Close(); err != nil {
+		return nil, fmt.Errorf("could not close response body: %w", err)
+	}
+	timings.Total = time.Since(start)
+
+	body, compression, err := decodeResponseBody(t.Response, body)
+	if err != nil {
+		return nil, err
+	}
+	t.Response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	ctx = check.ContextWithTimings(ctx, timings)
+	ctx = check.ContextWithCompression(ctx, compression)
+
+	if err := check.CaptureAll(ctx, t.Response, t.Captures); err != nil {
+		return body, err
+	}
+
+	reportAll := ReportAllFailuresFromContext(ctx)
+	if t.ReportAllFailures != nil {
+		reportAll = *t.ReportAllFailures
+	}
+
+	if reportAll {
+		if failures := check.RunChecks(ctx, t.Response, t.Checks); len(failures) > 0 {
+			return body, &check.ChecksFailedError{
+				Total:    len(t.Checks),
+				Failures: failures,
+			}
+		}
+		return body, nil
 	}
 
 	for _, c := range t.Checks {
 		err := c.Check(ctx, t.Response)
 		if err != nil {
-			return fmt.Errorf("failed `%T` check: %w", c, err)
+			return body, fmt.Errorf("failed `%T` check: %w", c, err)
+		}
+	}
+
+	return body, nil
+}
+
+// runPages follows the next page links of a paginated test, running the test against each page,
+// and then runs the page-level assertions.
+// t.Request and t.Response are replaced with those of each page, so they describe the last page requested.
+// body is the response body of the first page, which has already been run.
+func runPages(ctx context.Context, t *Test, httpClient *http.Client, logger *log.Logger, body []byte) error {
+	maxPages := t.Paginate.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+
+	items := &pageItems{
+		pagination: t.Paginate,
+		seen:       make(map[string]int),
+	}
+
+	// each page is requested using a copy of the first request
+	firstRequest := t.Request
+
+	page := 1
+	for {
+		pageURL := t.Request.URL.String()
+		if err := items.add(page, pageURL, body); err != nil {
+			return err
+		}
+
+		nextURL, err := t.Paginate.nextPageURL(t.Response, body)
+		if err != nil {
+			return &PageFailedError{
+				Page: page,
+				URL:  pageURL,
+				Err:  err,
+			}
+		}
+		if nextURL == nil {
+			break
+		}
+		if page >= maxPages {
+			if logger != nil {
+				logger.Printf("test `%s` stopped paginating at the limit of %d pages\n", t.Name, maxPages)
+			}
+			break
+		}
+
+		page++
+		t.Request, err = copyRequest(ctx, firstRequest, nextURL.String())
+		if err != nil {
+			return err
+		}
+		body, err = runPage(check.ContextWithPage(ctx, page), t, httpClient)
+		if err != nil {
+			return &PageFailedError{
+				Page: page,
+				URL:  nextURL.String(),
+				Err:  err,
+			}
+		}
+	}
+
+	if t.Paginate.TotalItems != nil && items.count != *t.Paginate.TotalItems {
+		return &UnexpectedItemCountError{
+			Pages:    page,
+			Expected: *t.Paginate.TotalItems,
+			Actual:   items.count,
 		}
 	}
 
@@ -68,6 +227,13 @@ type RunAllArgs struct {
 	IgnoreAllOnFailure bool
 	// IgnoreGroupOnFailure should be true if when a test fails you want no more tests in the failed group to be executed
 	IgnoreGroupOnFailure bool
+	// ReportAllFailures should be true if every check in a test should be run and all failures reported, rather than stopping at the first failure
+	ReportAllFailures bool
+	// CookieJar defines whether cookies set by responses are stored and sent in subsequent requests, and which tests share them.
+	// If this is not CookieJarNone, any Jar set on HTTPClient is replaced
+	CookieJar CookieJarScope
+	// ColourOutput should be true if you want errors such as JSON diffs to be logged with ANSI colour codes
+	ColourOutput bool
 }
 
 // RunAllResult is the response given from RunAll
@@ -93,8 +259,17 @@ func RunAll(ctx context.Context, args RunAllArgs, tests ...*Test) RunAllResult {
 		ctx = check.ContextWithData(ctx, testData)
 	}
 
+	if args.ReportAllFailures {
+		ctx = ContextWithReportAllFailures(ctx, true)
+	}
+
 	sem := make(chan struct{}, args.MaxConcurrentTests)
 
+	suiteHTTPClient := args.HTTPClient
+	if args.CookieJar == CookieJarSuite {
+		suiteHTTPClient = withCookieJar(args.HTTPClient)
+	}
+
 	groupedTests := groupTests(tests...)
 
 	if args.Groups != nil {
@@ -137,6 +312,11 @@ groupLoop:
 		groupResMu := sync.Mutex{}
 		groupRes := &RunAllResult{}
 
+		groupHTTPClient := suiteHTTPClient
+		if args.CookieJar == CookieJarGroup {
+			groupHTTPClient = withCookieJar(args.HTTPClient)
+		}
+
 	groupOrderLoop:
 		for i := groupTests.minOrder; i <= groupTests.maxOrder; i++ {
 			if args.Logger != nil {
@@ -186,7 +366,7 @@ groupLoop:
 						return
 					}
 
-					err := Run(ctx, t, args.HTTPClient, args.Logger)
+					err := Run(ctx, t, groupHTTPClient, args.Logger)
 
 					groupResMu.Lock()
 					defer groupResMu.Unlock()
@@ -195,7 +375,7 @@ groupLoop:
 					if err != nil {
 						groupRes.Failed++
 						if args.Logger != nil {
-							args.Logger.Printf("test `%s` failed: %s\nRequest:\n%s\nResponse:\n%s\n", t.Name, err, fmtRequest(t.Request), fmtResponse(t.Response))
+							args.Logger.Printf("test `%s` failed: %s\nRequest:\n%s\nResponse:\n%s\n", t.Name, check.ErrorString(err, args.ColourOutput), fmtRequest(t.Request), fmtResponse(t.Response))
 						}
 					} else {
 						groupRes.Passed++
@@ -224,6 +404,15 @@ groupLoop:
 	return *overallRes
 }
 
+// withCookieJar returns a copy of the given client that uses a new, empty cookie jar
+func withCookieJar(httpClient *http.Client) *http.Client {
+	// cookiejar.New only returns an error if the given options are invalid
+	jar, _ := cookiejar.New(nil)
+	c := *httpClient
+	c.Jar = jar
+	return &c
+}
+
 func fmtRequest(r *http.Request) string {
 	if r == nil {
 		return ""
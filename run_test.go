@@ -1,13 +1,27 @@
 package apitestr_test
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/tomwright/apitestr"
+	"github.com/tomwright/apitestr/check"
 	"github.com/tomwright/apitestr/parse"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestRun(t *testing.T) {
@@ -35,3 +49,681 @@ func TestRun(t *testing.T) {
 		return
 	}
 }
+
+func TestRun_HTMLCaptureCSRFToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<html><body><form action="/login"><input type="hidden" name="csrf" value="abc123"><input name="user"></form></body></html>`))
+		case http.MethodPost:
+			body, _ := ioutil.ReadAll(r.Body)
+			if string(body) != "csrf=abc123" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := apitestr.ContextWithBaseURL(context.Background(), ts.URL)
+	ctx = apitestr.ContextWithRequestInitFunc(ctx, "replacements", apitestr.RequestReplacements)
+	ctx = check.ContextWithData(ctx, make(map[string]interface{}))
+
+	getTest, err := parse.Parse(ctx, []byte(`{
+		"version": 1,
+		"request": {"method": "GET", "path": "/login"},
+		"checks": [
+			{"type": "htmlBodySelectorCount", "data": {"selector": "form input", "value": 2}},
+			{"type": "htmlBodySelectorExists", "data": {"selector": "form input[name=csrf]", "attribute": "value", "dataId": "csrf"}}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing get test: %s", err)
+	}
+	postTest, err := parse.Parse(ctx, []byte(`{
+		"version": 1,
+		"request": {"method": "POST", "path": "/login", "body": "csrf=:csrf:", "init": {"replacements": {":csrf:": "$.csrf"}}},
+		"checks": [
+			{"type": "statusCodeEqual", "data": {"value": 204}}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing post test: %s", err)
+	}
+
+	if err := apitestr.Run(ctx, getTest, nil, nil); err != nil {
+		t.Fatalf("unexpected error in get test: %s", err)
+	}
+	if err := apitestr.Run(ctx, postTest, nil, nil); err != nil {
+		t.Fatalf("unexpected error in post test: %s", err)
+	}
+}
+
+func TestRun_Snapshot(t *testing.T) {
+	name := "Tom"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		res, _ := json.Marshal(map[string]interface{}{
+			"name":      name,
+			"updatedAt": time.Now().String(),
+			"items":     []interface{}{map[string]interface{}{"id": time.Now().UnixNano(), "title": "a"}},
+		})
+		_, _ = w.Write(res)
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "apitestr")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	testFile := filepath.Join(dir, "snapshot.json")
+	err = ioutil.WriteFile(testFile, []byte(`{
+		"version": 1,
+		"request": {"method": "GET", "path": "/"},
+		"checks": [
+			{"type": "snapshot", "data": {"status": true, "headers": ["Content-Type"], "ignore": ["body.updatedAt", "body.items.#.id"]}}
+		]
+	}`), 0644)
+	if err != nil {
+		t.Fatalf("could not write test file: %s", err)
+	}
+
+	ctx := apitestr.ContextWithBaseURL(context.Background(), ts.URL)
+
+	run := func(ctx context.Context) error {
+		te, err := parse.File(ctx, testFile)
+		if err != nil {
+			t.Fatalf("unexpected error parsing file: %s", err)
+		}
+		return apitestr.Run(ctx, te, nil, nil)
+	}
+
+	var missingErr *check.SnapshotMissingError
+	if err := run(ctx); !errors.As(err, &missingErr) {
+		t.Fatalf("expected snapshot missing error, got %v", err)
+	}
+
+	if err := run(check.ContextWithUpdateSnapshots(ctx, true)); err != nil {
+		t.Fatalf("unexpected error updating snapshot: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "_snapshot.0.snapshot.json")); err != nil {
+		t.Fatalf("expected snapshot file to exist: %s", err)
+	}
+
+	if err := run(ctx); err != nil {
+		t.Fatalf("unexpected error comparing snapshot: %s", err)
+	}
+
+	name = "Jim"
+	var mismatchErr *check.SnapshotMismatchError
+	if err := run(ctx); !errors.As(err, &mismatchErr) {
+		t.Fatalf("expected snapshot mismatch error, got %v", err)
+	}
+	if exp, got := "~ body.name: expected \"Tom\", got \"Jim\"", mismatchErr.Differences.String(); exp != got {
+		t.Errorf("expected diff `%s`, got `%s`", exp, got)
+	}
+}
+
+func TestRunAll_CookieJar(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123", Path: "/", MaxAge: 3600, HttpOnly: true, SameSite: http.SameSiteLaxMode})
+		case "/me":
+			if c, err := r.Cookie("session"); err != nil || c.Value != "abc123" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}
+	}))
+	defer ts.Close()
+
+	ctx := apitestr.ContextWithBaseURL(context.Background(), ts.URL)
+
+	parseTests := func() []*apitestr.Test {
+		login, err := parse.Parse(ctx, []byte(`{
+			"version": 1,
+			"order": 0,
+			"request": {"method": "POST", "path": "/login"},
+			"checks": [
+				{"type": "cookie", "data": {"name": "session", "pattern": "^[a-z0-9]+$", "httpOnly": true, "secure": false, "sameSite": "lax", "minLifetime": "30m", "dataId": "session"}}
+			]
+		}`))
+		if err != nil {
+			t.Fatalf("unexpected error parsing login test: %s", err)
+		}
+		me, err := parse.Parse(ctx, []byte(`{
+			"version": 1,
+			"order": 1,
+			"request": {"method": "GET", "path": "/me"},
+			"checks": [
+				{"type": "statusCodeEqual", "data": {"value": 200}}
+			]
+		}`))
+		if err != nil {
+			t.Fatalf("unexpected error parsing me test: %s", err)
+		}
+		return []*apitestr.Test{login, me}
+	}
+
+	tests := [...]struct {
+		scope  apitestr.CookieJarScope
+		passed int
+		failed int
+	}{
+		{scope: apitestr.CookieJarNone, passed: 1, failed: 1},
+		{scope: apitestr.CookieJarSuite, passed: 2},
+		{scope: apitestr.CookieJarGroup, passed: 2},
+	}
+
+	for _, tc := range tests {
+		res := apitestr.RunAll(ctx, apitestr.RunAllArgs{CookieJar: tc.scope}, parseTests()...)
+		if res.Passed != tc.passed || res.Failed != tc.failed {
+			t.Errorf("scope `%s`: expected %d passed and %d failed, got %d passed and %d failed", tc.scope, tc.passed, tc.failed, res.Passed, res.Failed)
+		}
+	}
+}
+
+func TestRun_ReportAllFailures(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"boom"}`))
+	}))
+	defer ts.Close()
+
+	ctx := apitestr.ContextWithBaseURL(context.Background(), ts.URL)
+
+	data := []byte(`{
+		"version": 1,
+		"request": {"method": "GET", "path": "/"},
+		"checks": [
+			{"type": "statusCodeEqual", "data": {"value": 200}},
+			{"type": "jsonBodyQueryExists", "data": {"query": "error"}},
+			{"type": "jsonBodyQueryExists", "data": {"query": "id"}}
+		]
+	}`)
+
+	te, err := parse.Parse(ctx, data)
+	if err != nil {
+		t.Fatalf("unexpected error parsing test: %s", err)
+	}
+
+	err = apitestr.Run(ctx, te, nil, nil)
+	if exp, got := "failed `*check.StatusCodeEqualChecker` check: unexpected status code: expected 200, got 500", fmt.Sprint(err); exp != got {
+		t.Errorf("expected error `%s`, got `%s`", exp, got)
+	}
+
+	te, err = parse.Parse(ctx, data)
+	if err != nil {
+		t.Fatalf("unexpected error parsing test: %s", err)
+	}
+
+	err = apitestr.Run(apitestr.ContextWithReportAllFailures(ctx, true), te, nil, nil)
+	exp := "2 of 3 checks failed:\n" +
+		"  [0] `*check.StatusCodeEqualChecker`: unexpected status code: expected 200, got 500\n" +
+		"  [2] `*check.BodyJSONQueryExistsChecker`: value at id is missing"
+	if got := fmt.Sprint(err); exp != got {
+		t.Errorf("expected error:\n%s\ngot:\n%s", exp, got)
+	}
+
+	var failedErr *check.ChecksFailedError
+	if !errors.As(err, &failedErr) || len(failedErr.Failures) != 2 {
+		t.Errorf("expected ChecksFailedError with 2 failures, got %#v", err)
+	}
+
+	te, err = parse.Parse(ctx, []byte(strings.Replace(string(data), `"version": 1,`, `"version": 1, "reportAllFailures": false,`, 1)))
+	if err != nil {
+		t.Fatalf("unexpected error parsing test: %s", err)
+	}
+
+	err = apitestr.Run(apitestr.ContextWithReportAllFailures(ctx, true), te, nil, nil)
+	if exp, got := "failed `*check.StatusCodeEqualChecker` check: unexpected status code: expected 200, got 500", fmt.Sprint(err); exp != got {
+		t.Errorf("expected error `%s`, got `%s`", exp, got)
+	}
+}
+
+func TestRun_Capture(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/users/123")
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cr3t"})
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "123", "name": "Tom", "message": "token=abc;"}`))
+	}))
+	defer ts.Close()
+
+	ctx := apitestr.ContextWithBaseURL(context.Background(), ts.URL)
+	data := make(map[string]interface{})
+	ctx = check.ContextWithData(ctx, data)
+
+	test, err := parse.Parse(ctx, []byte(`{
+		"version": 1,
+		"request": {"method": "POST", "path": "/users"},
+		"capture": {
+			"status": {"from": "status"},
+			"location": {"from": "header", "name": "location"},
+			"session": {"from": "cookie", "name": "session"},
+			"userId": {"from": "json", "query": "id"},
+			"token": {"from": "regex", "pattern": "token=(\\w+);"},
+			"body": {"from": "body"}
+		},
+		"checks": [
+			{"type": "dataEqual", "data": {"id": "userId", "value": "123"}},
+			{"type": "dataEqual", "data": {"id": "status", "value": 201}}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing test: %s", err)
+	}
+
+	if err := apitestr.Run(ctx, test, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"status":   float64(201),
+		"location": "/users/123",
+		"session":  "s3cr3t",
+		"userId":   "123",
+		"token":    "abc",
+		"body":     `{"id": "123", "name": "Tom", "message": "token=abc;"}`,
+	}
+	if !reflect.DeepEqual(expected, data) {
+		t.Errorf("expected data %v, got %v", expected, data)
+	}
+
+	test, err = parse.Parse(ctx, []byte(`{
+		"version": 1,
+		"request": {"method": "POST", "path": "/users"},
+		"capture": {
+			"missing": {"from": "header", "name": "X-Missing"}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing test: %s", err)
+	}
+
+	err = apitestr.Run(ctx, test, nil, nil)
+	if exp, got := "could not capture `missing`: header X-Missing was not set", fmt.Sprint(err); exp != got {
+		t.Errorf("expected error %q, got %q", exp, got)
+	}
+}
+
+func TestRun_Paginate(t *testing.T) {
+	pages := map[string]string{
+		"":  `{"items": [{"id": 1}, {"id": 2}], "next": "b"}`,
+		"b": `{"items": [{"id": 3}, {"id": 4}], "next": "c"}`,
+		"c": `{"items": [{"id": 5}], "next": null}`,
+		"d": `{"items": [{"id": 4}, {"id": 6}], "next": null}`,
+	}
+	requested := make([]string, 0)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if body, _ := ioutil.ReadAll(r.Body); r.Method == http.MethodPost && (string(body) != `{"filter":"all"}` || r.ContentLength != int64(len(body))) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requested = append(requested, r.URL.RequestURI())
+		page := r.URL.Query().Get("page")
+		switch page {
+		case "":
+			w.Header().Set("Link", `</link?page=b>; rel="next", </link?page=c>; rel="last"`)
+		case "b":
+			w.Header().Set("Link", `<https://example.com>; rel="prev", </link?page=d>; rel="next"`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(pages[page]))
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		desc              string
+		method            string
+		path              string
+		paginate          string
+		checks            string
+		expectedRequested []string
+		expectedErr       string
+	}{
+		{
+			desc:              "cursor",
+			path:              "/cursor?limit=2",
+			paginate:          `{"nextQuery": "next", "cursorParam": "page", "itemsQuery": "items", "uniqueQuery": "id", "totalItems": 5}`,
+			expectedRequested: []string{"/cursor?limit=2", "/cursor?limit=2&page=b", "/cursor?limit=2&page=c"},
+		},
+		{
+			desc:              "post body",
+			method:            http.MethodPost,
+			path:              "/search",
+			paginate:          `{"nextQuery": "next", "cursorParam": "page", "itemsQuery": "items", "totalItems": 5}`,
+			checks:            `[{"type": "statusCodeEqual", "data": {"value": 200}}]`,
+			expectedRequested: []string{"/search", "/search?page=b", "/search?page=c"},
+		},
+		{
+			desc:              "max pages",
+			path:              "/cursor",
+			paginate:          `{"nextQuery": "next", "cursorParam": "page", "maxPages": 2, "itemsQuery": "items", "totalItems": 5}`,
+			expectedRequested: []string{"/cursor", "/cursor?page=b"},
+			expectedErr:       "unexpected item count across 2 page(s): expected 5, got 4",
+		},
+		{
+			desc:              "link header",
+			path:              "/link",
+			paginate:          `{"itemsQuery": "items", "uniqueQuery": "id"}`,
+			expectedRequested: []string{"/link", "/link?page=b", "/link?page=d"},
+			expectedErr:       "duplicate item id 4 on page 3, first seen on page 2",
+		},
+		{
+			desc:              "check fails on a page",
+			path:              "/cursor",
+			paginate:          `{"nextQuery": "next", "cursorParam": "page"}`,
+			checks:            `[{"type": "jsonBodyQueryLength", "data": {"query": "items", "value": 2}}]`,
+			expectedRequested: []string{"/cursor", "/cursor?page=b", "/cursor?page=c"},
+			expectedErr:       fmt.Sprintf("page 3 (%s/cursor?page=c) failed: failed `*check.BodyJSONQueryLengthChecker` check: unexpected length at items: expected 2, got 1", ts.URL),
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			requested = requested[:0]
+
+			ctx := apitestr.ContextWithBaseURL(context.Background(), ts.URL)
+
+			checks := tc.checks
+			if checks == "" {
+				checks = "[]"
+			}
+
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			test, err := parse.Parse(ctx, []byte(fmt.Sprintf(`{
+				"version": 1,
+				"request": {"method": %q, "path": %q, "headers": {"Authorization": "Bearer abc", "Content-Type": "application/json"}, "body": {"filter": "all"}},
+				"paginate": %s,
+				"checks": %s
+			}`, method, tc.path, tc.paginate, checks)))
+			if err != nil {
+				t.Fatalf("unexpected error parsing test: %s", err)
+			}
+
+			err = apitestr.Run(ctx, test, nil, nil)
+			if exp, got := tc.expectedErr, fmt.Sprint(err); (exp != "" || err != nil) && exp != got {
+				t.Errorf("expected error %q, got %q", exp, got)
+			}
+			if !reflect.DeepEqual(tc.expectedRequested, requested) {
+				t.Errorf("expected requests %v, got %v", tc.expectedRequested, requested)
+			}
+		})
+	}
+}
+
+func TestRun_Poll(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"job":"export"}` || r.ContentLength != int64(len(body)) || len(r.TransferEncoding) > 0 || r.Host != "jobs.example.com" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		attempts++
+		status := "pending"
+		if attempts >= 3 {
+			status = "done"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"status": %q}`, status)))
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		desc             string
+		poll             string
+		expectedAttempts int
+		expectedErr      string
+	}{
+		{
+			desc:             "passes",
+			poll:             `{"interval": "5ms", "backoff": 2, "timeout": "5s"}`,
+			expectedAttempts: 3,
+		},
+		{
+			desc:             "max attempts",
+			poll:             `{"interval": "5ms", "maxAttempts": 2}`,
+			expectedAttempts: 2,
+			expectedErr:      "failed `*check.BodyJSONQueryEqualChecker` check: unexpected value at status:",
+		},
+		{
+			desc:             "timeout",
+			poll:             `{"interval": "50ms", "timeout": "20ms"}`,
+			expectedAttempts: 2,
+			expectedErr:      "failed `*check.BodyJSONQueryEqualChecker` check: unexpected value at status:",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			attempts = 0
+
+			ctx := apitestr.ContextWithBaseURL(context.Background(), ts.URL)
+
+			test, err := parse.Parse(ctx, []byte(fmt.Sprintf(`{
+				"version": 1,
+				"request": {"method": "POST", "path": "/jobs/1", "headers": {"Content-Type": "application/json"}, "body": {"job": "export"}},
+				"poll": %s,
+				"checks": [
+					{"type": "jsonBodyQueryEqual", "data": {"query": "status", "value": "done"}}
+				]
+			}`, tc.poll)))
+			if err != nil {
+				t.Fatalf("unexpected error parsing test: %s", err)
+			}
+			test.Request.Host = "jobs.example.com"
+
+			err = apitestr.Run(ctx, test, nil, nil)
+			if attempts != tc.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tc.expectedAttempts, attempts)
+			}
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			var pollErr *apitestr.PollFailedError
+			if !errors.As(err, &pollErr) {
+				t.Fatalf("expected *apitestr.PollFailedError, got %T: %v", err, err)
+			}
+			if pollErr.Attempts != tc.expectedAttempts {
+				t.Errorf("expected error with %d attempts, got %d", tc.expectedAttempts, pollErr.Attempts)
+			}
+			if got := pollErr.Err.Error(); !strings.HasPrefix(got, tc.expectedErr) {
+				t.Errorf("expected error to start with %q, got %q", tc.expectedErr, got)
+			}
+		})
+	}
+}
+
+func TestRun_Compression(t *testing.T) {
+	body := strings.Repeat(`{"name": "Tom"}`, 100)
+
+	encode := func(w io.WriteCloser) {
+		_, _ = w.Write([]byte(body))
+		_ = w.Close()
+	}
+	encoded := map[string][]byte{}
+	buf := &bytes.Buffer{}
+	encode(gzip.NewWriter(buf))
+	encoded["gzip"] = buf.Bytes()
+	buf = &bytes.Buffer{}
+	encode(zlib.NewWriter(buf))
+	encoded["deflate"] = buf.Bytes()
+	buf = &bytes.Buffer{}
+	flateWriter, _ := flate.NewWriter(buf, flate.DefaultCompression)
+	encode(flateWriter)
+	encoded["raw-deflate"] = buf.Bytes()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := strings.TrimPrefix(r.URL.Path, "/")
+		if encoding == "identity" || !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		switch encoding {
+		case "raw-deflate":
+			w.Header().Set("Content-Encoding", "deflate")
+		case "upper-gzip":
+			encoding = "gzip"
+			w.Header().Set("Content-Encoding", " GZIP ")
+		default:
+			w.Header().Set("Content-Encoding", encoding)
+		}
+		_, _ = w.Write(encoded[encoding])
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		desc           string
+		path           string
+		acceptEncoding string
+		data           string
+		expectedErr    string
+	}{
+		{
+			desc:           "gzip",
+			path:           "/gzip",
+			acceptEncoding: "gzip, deflate",
+			data:           fmt.Sprintf(`{"encoding": "gzip", "maxCompressedSize": %d, "minRatio": 10}`, len(encoded["gzip"])),
+		},
+		{
+			desc:           "deflate",
+			path:           "/deflate",
+			acceptEncoding: "gzip, deflate",
+			data:           `{"encoding": "deflate"}`,
+		},
+		{
+			desc:           "raw deflate",
+			path:           "/raw-deflate",
+			acceptEncoding: "gzip, deflate",
+			data:           `{"encoding": "deflate"}`,
+		},
+		{
+			desc:           "upper case encoding",
+			path:           "/upper-gzip",
+			acceptEncoding: "gzip",
+			data:           `{"encoding": "gzip"}`,
+		},
+		{
+			desc:           "identity",
+			path:           "/identity",
+			acceptEncoding: "gzip, deflate",
+			data:           `{"encoding": "gzip"}`,
+			expectedErr:    "failed `*check.ContentEncodingChecker` check: unexpected content encoding: expected gzip, got identity",
+		},
+		{
+			desc:           "too large",
+			path:           "/gzip",
+			acceptEncoding: "gzip",
+			data:           `{"maxCompressedSize": 10}`,
+			expectedErr:    fmt.Sprintf("failed `*check.ContentEncodingChecker` check: unexpected compression: expected at most 10 bytes compressed, got %d bytes decompressed to %d bytes", len(encoded["gzip"]), len(body)),
+		},
+		{
+			desc: "decoded by transport",
+			path: "/gzip",
+			data: `{"encoding": "gzip", "minRatio": 2}`,
+			expectedErr: "failed `*check.ContentEncodingChecker` check: unexpected compression: expected a compression ratio of at least 2, " +
+				"but the compressed size is unknown as the http client decoded the body: set the Accept-Encoding request header to prevent this",
+		},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := apitestr.ContextWithBaseURL(context.Background(), ts.URL)
+
+			headers := "{}"
+			if tc.acceptEncoding != "" {
+				headers = fmt.Sprintf(`{"Accept-Encoding": %q}`, tc.acceptEncoding)
+			}
+
+			test, err := parse.Parse(ctx, []byte(fmt.Sprintf(`{
+				"version": 1,
+				"request": {"method": "GET", "path": %q, "headers": %s},
+				"checks": [
+					{"type": "bodyEqual", "data": {"value": %q}},
+					{"type": "contentEncoding", "data": %s}
+				]
+			}`, tc.path, headers, body, tc.data)))
+			if err != nil {
+				t.Fatalf("unexpected error parsing test: %s", err)
+			}
+
+			err = apitestr.Run(ctx, test, nil, nil)
+			if exp, got := tc.expectedErr, fmt.Sprint(err); (exp != "" || err != nil) && exp != got {
+				t.Errorf("expected error %q, got %q", exp, got)
+			}
+		})
+	}
+}
+
+func TestRun_CompressionWithoutContent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		switch r.URL.Path {
+		case "/no-content":
+			w.WriteHeader(http.StatusNoContent)
+		case "/not-modified":
+			w.WriteHeader(http.StatusNotModified)
+		case "/empty":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.Header().Set("Content-Length", "100")
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{method: http.MethodGet, path: "/no-content", status: http.StatusNoContent},
+		{method: http.MethodGet, path: "/not-modified", status: http.StatusNotModified},
+		{method: http.MethodGet, path: "/empty", status: http.StatusOK},
+		{method: http.MethodHead, path: "/head", status: http.StatusOK},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			ctx := apitestr.ContextWithBaseURL(context.Background(), ts.URL)
+
+			test, err := parse.Parse(ctx, []byte(fmt.Sprintf(`{
+				"version": 1,
+				"request": {"method": %q, "path": %q, "headers": {"Accept-Encoding": "gzip"}},
+				"checks": [
+					{"type": "statusCodeEqual", "data": {"value": %d}},
+					{"type": "bodyEqual", "data": {"value": ""}},
+					{"type": "contentEncoding", "data": {"encoding": "gzip"}}
+				]
+			}`, tc.method, tc.path, tc.status)))
+			if err != nil {
+				t.Fatalf("unexpected error parsing test: %s", err)
+			}
+
+			if err := apitestr.Run(ctx, test, nil, nil); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -13,14 +13,23 @@ type Test struct {
 	Group string
 	// Order specified the order in which it will be run. Tests with the same order will be executed at the same time
 	Order int
+	// Captures contains values to extract from the response and store in the context data before the checks are run
+	Captures []check.Capture
 	// Checks contains all checks contained in this test
 	Checks []check.Checker
-	// Request contains the http request being made
+	// Request contains the http request being made. For paginated tests, it is replaced by the request for the last page requested
 	Request *http.Request
-	// Response contains the http response
+	// Response contains the http response. For paginated tests, it is the response for the last page requested
 	Response *http.Response
 	// RequestInitFuncs contains a set of functions used to initialise the request
 	RequestInitFuncs []RequestInitFunc
 	// RequestInitFuncsData contains the arguments to be given to the init func with the matching index
 	RequestInitFuncsData []map[string]interface{}
+	// ReportAllFailures should be true if every check should be run and all failures reported, rather than stopping at the first failure.
+	// If nil, the value from ReportAllFailuresFromContext is used
+	ReportAllFailures *bool
+	// Paginate, if not nil, defines how to follow the pages of a paginated response
+	Paginate *Pagination
+	// Poll, if not nil, defines how the test is retried until all checks pass
+	Poll *Poll
 }